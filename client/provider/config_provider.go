@@ -3,6 +3,7 @@ package provider
 import (
 	"fmt"
 	"strconv"
+	"sync"
 
 	pkgConfig "github.com/gojek/stevedore/pkg/config"
 	"github.com/gojek/stevedore/pkg/plugin"
@@ -12,15 +13,34 @@ import (
 )
 
 func init() {
-	defaultPlugins["consul"] = ClientPlugin{PluginImpl: ConsulConfigProvider{conf: config.NewConfig()}}
+	defaultPlugins["consul"] = ClientPlugin{PluginImpl: newConsulConfigProvider(func() config.Config { return config.NewConfig() })}
 }
 
-// ConsulConfigProvider is the struct represents the ConfigProvider for Consul
+// ConsulConfigProvider is the struct represents the ConfigProvider for Consul.
+// It is safe to be called concurrently, as each source is loaded once into a config of its own
 type ConsulConfigProvider struct {
+	newConfig func() config.Config
+	sources   *consulSources
+}
+
+// consulSources holds the configs loaded from consul by the address, prefix and strip-prefix of the source
+type consulSources struct {
+	mutex   sync.Mutex
+	entries map[string]*consulSource
+}
+
+type consulSource struct {
+	once sync.Once
 	conf config.Config
+	err  error
+}
+
+func newConsulConfigProvider(newConfig func() config.Config) ConsulConfigProvider {
+	return ConsulConfigProvider{newConfig: newConfig, sources: &consulSources{entries: map[string]*consulSource{}}}
 }
 
 var _ pkgConfig.Provider = ConsulConfigProvider{}
+var _ pkgConfig.CacheableProvider = ConsulConfigProvider{}
 var _ plugin.ConfigInterface = ConsulConfigProvider{}
 
 const (
@@ -73,13 +93,7 @@ func (p ConsulConfigProvider) Fetch(
 		return nil, fmt.Errorf("invalid consul configs: %v", err)
 	}
 
-	consulSource := consul.NewSource(
-		consul.WithAddress(address),
-		consul.WithPrefix(prefix),
-		consul.StripPrefix(shouldStripPrefix),
-	)
-
-	err = p.conf.Load(consulSource)
+	conf, err := p.load(address, prefix, shouldStripPrefix)
 	if err != nil {
 		return nil, fmt.Errorf("error loading from consul: %v", err)
 	}
@@ -87,7 +101,7 @@ func (p ConsulConfigProvider) Fetch(
 
 	for _, path := range consulConfig.Path {
 
-		err = p.conf.Get(path).Scan(&finalConfigs)
+		err = conf.Get(path).Scan(&finalConfigs)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode configs under path %s: %v", path, err)
 		}
@@ -95,6 +109,35 @@ func (p ConsulConfigProvider) Fetch(
 	return finalConfigs, nil
 }
 
+// load returns the config loaded from the source, loading it if it is not already loaded.
+// Concurrent loads of the same source wait for the first one
+func (p ConsulConfigProvider) load(address, prefix string, stripPrefix bool) (config.Config, error) {
+	key := fmt.Sprintf("%s|%s|%t", address, prefix, stripPrefix)
+	p.sources.mutex.Lock()
+	source, ok := p.sources.entries[key]
+	if !ok {
+		source = &consulSource{}
+		p.sources.entries[key] = source
+	}
+	p.sources.mutex.Unlock()
+
+	source.once.Do(func() {
+		conf := p.newConfig()
+		source.err = conf.Load(consul.NewSource(
+			consul.WithAddress(address),
+			consul.WithPrefix(prefix),
+			consul.StripPrefix(stripPrefix),
+		))
+		source.conf = conf
+	})
+	return source.conf, source.err
+}
+
+// Cacheable returns true, as the configs are fetched from the remote consul
+func (ConsulConfigProvider) Cacheable() bool {
+	return true
+}
+
 // Init the ConsulConfigProvider
 func (ConsulConfigProvider) Init() error {
 	return nil
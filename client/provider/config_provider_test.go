@@ -2,11 +2,14 @@ package provider
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/gojek/stevedore/client/internal/mocks/micro/go-micro"
 	"github.com/gojek/stevedore/pkg/plugin"
 	"github.com/golang/mock/gomock"
+	"github.com/micro/go-micro/config"
+	"github.com/micro/go-micro/config/reader"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,7 +18,7 @@ func TestConsulConfigProvider_Fetch(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockConfig := mocks.NewMockConfig(ctrl)
-	c := ConsulConfigProvider{conf: mockConfig}
+	newConfig := func() config.Config { return mockConfig }
 	mockValue := mocks.NewMockValue(ctrl)
 
 	type args struct {
@@ -184,7 +187,7 @@ func TestConsulConfigProvider_Fetch(t *testing.T) {
 			if test.preRun != nil {
 				test.preRun()
 			}
-			got, err := c.Fetch(test.args.context, test.args.data)
+			got, err := newConsulConfigProvider(newConfig).Fetch(test.args.context, test.args.data)
 
 			assert.Equal(t, test.err, err)
 			assert.Equal(t, test.want, got)
@@ -192,6 +195,44 @@ func TestConsulConfigProvider_Fetch(t *testing.T) {
 	}
 }
 
+func TestConsulConfigProvider_FetchLoadsOnce(t *testing.T) {
+	t.Run("should load each source once across the fetches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var loaded []string
+		newConfig := func() config.Config {
+			mockConfig := mocks.NewMockConfig(ctrl)
+			mockConfig.EXPECT().Load(gomock.Any()).Return(nil).Times(1)
+			mockConfig.EXPECT().Get(gomock.Any()).DoAndReturn(func(path ...string) reader.Value {
+				mockValue := mocks.NewMockValue(ctrl)
+				mockValue.EXPECT().Scan(gomock.Any()).Return(nil)
+				return mockValue
+			}).AnyTimes()
+			loaded = append(loaded, "source")
+			return mockConfig
+		}
+		c := newConsulConfigProvider(newConfig)
+		context := map[string]string{"host": "consul", "port": "1234", "prefix": "prefix", "strip-prefix": "false"}
+		data := map[interface{}]interface{}{"path": []string{"path1"}}
+
+		var wait sync.WaitGroup
+		for index := 0; index < 4; index++ {
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				_, err := c.Fetch(context, data)
+				assert.NoError(t, err)
+			}()
+		}
+		wait.Wait()
+		_, err := c.Fetch(map[string]string{"host": "consul", "port": "1234", "prefix": "other", "strip-prefix": "false"}, data)
+
+		assert.NoError(t, err)
+		assert.Len(t, loaded, 2)
+	})
+}
+
 func TestConsulConfigProvider_Flags(t *testing.T) {
 	t.Run("should returns consul flags", func(t *testing.T) {
 		c := ConsulConfigProvider{}
@@ -69,4 +69,94 @@ contexts:
 		}
 		assert.Equal(t, stevedore.Context{}, context)
 	})
+	t.Run("should return the context along with the precedence of the conditions and providers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		contextString := `
+current: components
+conditionPrecedence:
+  - labels.region
+providerPrecedence:
+  - vault
+  - file
+strictConfigs: true
+contexts:
+  - name: components
+    environment: env
+    kubernetesContext: components
+    environmentType: staging
+    labels:
+      region: eu-west`
+
+		contextFile := "/mock/contextFile"
+		mockEnvironment := mocks.NewMockEnvironment(ctrl)
+		memFs := afero.NewMemMapFs()
+		mockEnvironment.EXPECT().Fetch().Return(map[string]interface{}{})
+		_ = afero.WriteFile(memFs, contextFile, []byte(contextString), 0644)
+
+		context, err := provider.NewContextProvider(memFs, contextFile, mockEnvironment).Context()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"labels.region"}, context.ConditionPrecedence)
+		assert.Equal(t, []string{"vault", "file"}, context.ProviderPrecedence)
+		assert.True(t, context.StrictConfigs)
+		assert.Equal(t, 32, stevedore.Conditions{"labels.region": "eu-west"}.Weight(context.ConditionWeights()))
+		assert.Equal(t, 0, stevedore.Conditions{"labels.region": "eu-west"}.Weight(stevedore.Context{}.ConditionWeights()))
+	})
+
+	t.Run("should return error if the condition precedence is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		contextString := `
+current: components
+conditionPrecedence:
+  - region
+contexts:
+  - name: components
+    environment: env
+    kubernetesContext: components
+    environmentType: staging`
+
+		contextFile := "/mock/contextFile"
+		mockEnvironment := mocks.NewMockEnvironment(ctrl)
+		memFs := afero.NewMemMapFs()
+		mockEnvironment.EXPECT().Fetch().Return(map[string]interface{}{})
+		_ = afero.WriteFile(memFs, contextFile, []byte(contextString), 0644)
+
+		_, err := provider.NewContextProvider(memFs, contextFile, mockEnvironment).Context()
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid condition region in precedence", err.Error())
+		}
+	})
+
+	t.Run("should return error if a provider is repeated in the precedence", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		contextString := `
+current: components
+providerPrecedence:
+  - vault
+  - vault
+contexts:
+  - name: components
+    environment: env
+    kubernetesContext: components
+    environmentType: staging`
+
+		contextFile := "/mock/contextFile"
+		mockEnvironment := mocks.NewMockEnvironment(ctrl)
+		memFs := afero.NewMemMapFs()
+		mockEnvironment.EXPECT().Fetch().Return(map[string]interface{}{})
+		_ = afero.WriteFile(memFs, contextFile, []byte(contextString), 0644)
+
+		_, err := provider.NewContextProvider(memFs, contextFile, mockEnvironment).Context()
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "provider vault is repeated in precedence", err.Error())
+		}
+	})
 }
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"sort"
+
 	"github.com/gojek/stevedore/pkg/stevedore"
 )
 
@@ -43,11 +45,20 @@ func (envsFiles EnvsFiles) extractEnvs() stevedore.EnvSpecifications {
 	return envs
 }
 
-// SortAndMerge will sort all envs based on a pre-determined order and merge all the env substitute into one
-func (envsFiles EnvsFiles) SortAndMerge(envs stevedore.Substitute) (stevedore.Substitute, error) {
+// Sensitive returns the variables which are listed as sensitive in the envs
+func (envsFiles EnvsFiles) Sensitive() stevedore.SensitiveVariables {
+	var result stevedore.SensitiveVariables
+	for _, env := range envsFiles.extractEnvs() {
+		result = append(result, env.Sensitive...)
+	}
+	return result
+}
+
+// SortAndMerge will sort all envs based on the weights of their conditions and merge all the env substitute into one
+func (envsFiles EnvsFiles) SortAndMerge(envs stevedore.Substitute, weights stevedore.Weights) (stevedore.Substitute, error) {
 	substitutes := stevedore.Substitute{}
 	applicableEnvs := envsFiles.extractEnvs()
-	applicableEnvs.Sort()
+	applicableEnvs.Sort(weights)
 
 	for _, env := range applicableEnvs {
 		result, err := substitutes.Merge(env.Values)
@@ -59,3 +70,29 @@ func (envsFiles EnvsFiles) SortAndMerge(envs stevedore.Substitute) (stevedore.Su
 
 	return substitutes.Merge(envs)
 }
+
+// Sources returns the source of each variable as resolved by SortAndMerge,
+// which is either the given envs or the env file and specification with the highest weight
+func (envsFiles EnvsFiles) Sources(envs stevedore.Substitute, weights stevedore.Weights) stevedore.Sources {
+	var sources []stevedore.Source
+	for _, envsFile := range envsFiles {
+		for _, env := range envsFile.EnvSpecifications {
+			for name := range env.Values {
+				source := stevedore.Source{Type: stevedore.EnvSource, File: envsFile.Name, Matches: env.Matches, Weight: env.Matches.Weight(weights), Variable: name}
+				sources = append(sources, source)
+			}
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].Weight < sources[j].Weight
+	})
+
+	result := stevedore.Sources{}
+	for _, source := range sources {
+		result[source.Variable] = source
+	}
+	for name := range envs {
+		result[name] = stevedore.Source{Type: stevedore.EnvironmentSource, Variable: name}
+	}
+	return result
+}
@@ -92,16 +92,69 @@ func TestEnvsFilesFilter(t *testing.T) {
 }
 
 func TestEnvsFilesSortAndMerge(t *testing.T) {
+	envsFiles := provider.EnvsFiles{
+		provider.EnvsFile{
+			Name: "x-env",
+			EnvSpecifications: stevedore.EnvSpecifications{
+				stevedore.EnvSpecification{
+					Matches: stevedore.Conditions{
+						"contextName": "cluster",
+					},
+					Values: stevedore.Substitute{"name": "x-env", "size": "8Gi"},
+				},
+			},
+		},
+		provider.EnvsFile{
+			Name: "y-env",
+			EnvSpecifications: stevedore.EnvSpecifications{
+				stevedore.EnvSpecification{
+					Matches: stevedore.Conditions{
+						"contextType": "env",
+					},
+					Values: stevedore.Substitute{"size": "4Gi", "persistence": "true"},
+				},
+			},
+		},
+		provider.EnvsFile{
+			Name: "z-env",
+			EnvSpecifications: stevedore.EnvSpecifications{
+				stevedore.EnvSpecification{
+					Matches: stevedore.Conditions{
+						"contextType": "env",
+						"environment": "staging",
+					},
+					Values: stevedore.Substitute{"size": "6Gi", "readonly": "true", "primary_slot_name": "readonly_cluster"},
+				},
+			},
+		},
+	}
+
 	t.Run("should merge all the values", func(t *testing.T) {
+		actual, err := envsFiles.SortAndMerge(stevedore.Substitute{"readonly": "false"}, stevedore.Context{}.ConditionWeights())
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"name": "x-env", "size": "8Gi", "persistence": "true", "readonly": "false", "primary_slot_name": "readonly_cluster"}, actual)
+	})
+
+	t.Run("should merge the values by the condition precedence of the context", func(t *testing.T) {
+		ctx := stevedore.Context{ConditionPrecedence: []string{stevedore.ConditionContextType}}
+
+		actual, err := envsFiles.SortAndMerge(stevedore.Substitute{"readonly": "false"}, ctx.ConditionWeights())
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"name": "x-env", "size": "6Gi", "persistence": "true", "readonly": "false", "primary_slot_name": "readonly_cluster"}, actual)
+	})
+}
+
+func TestEnvsFilesSources(t *testing.T) {
+	t.Run("should return the env file and specification with highest weight or the environment as the source of each variable", func(t *testing.T) {
 		envsFiles := provider.EnvsFiles{
 			provider.EnvsFile{
 				Name: "x-env",
 				EnvSpecifications: stevedore.EnvSpecifications{
 					stevedore.EnvSpecification{
-						Matches: stevedore.Conditions{
-							"contextName": "cluster",
-						},
-						Values: stevedore.Substitute{"name": "x-env", "size": "8Gi"},
+						Matches: stevedore.Conditions{"contextName": "components"},
+						Values:  stevedore.Substitute{"size": "20Gi"},
 					},
 				},
 			},
@@ -109,30 +162,20 @@ func TestEnvsFilesSortAndMerge(t *testing.T) {
 				Name: "y-env",
 				EnvSpecifications: stevedore.EnvSpecifications{
 					stevedore.EnvSpecification{
-						Matches: stevedore.Conditions{
-							"contextType": "env",
-						},
-						Values: stevedore.Substitute{"size": "4Gi", "persistence": "true"},
-					},
-				},
-			},
-			provider.EnvsFile{
-				Name: "z-env",
-				EnvSpecifications: stevedore.EnvSpecifications{
-					stevedore.EnvSpecification{
-						Matches: stevedore.Conditions{
-							"contextType": "env",
-							"environment": "staging",
-						},
-						Values: stevedore.Substitute{"size": "6Gi", "readonly": "true", "primary_slot_name": "readonly_cluster"},
+						Matches: stevedore.Conditions{"environment": "staging"},
+						Values:  stevedore.Substitute{"size": "10Gi", "persistence": "true", "HOME": "/tmp"},
 					},
 				},
 			},
 		}
+		expected := stevedore.Sources{
+			"size":        {Type: stevedore.EnvSource, File: "x-env", Matches: stevedore.Conditions{"contextName": "components"}, Weight: 8, Variable: "size"},
+			"persistence": {Type: stevedore.EnvSource, File: "y-env", Matches: stevedore.Conditions{"environment": "staging"}, Weight: 2, Variable: "persistence"},
+			"HOME":        {Type: stevedore.EnvironmentSource, Variable: "HOME"},
+		}
 
-		actual, err := envsFiles.SortAndMerge(stevedore.Substitute{"readonly": "false"})
+		actual := envsFiles.Sources(stevedore.Substitute{"HOME": "/root"}, stevedore.Context{}.ConditionWeights())
 
-		assert.NoError(t, err)
-		assert.Equal(t, stevedore.Substitute{"name": "x-env", "size": "8Gi", "persistence": "true", "readonly": "false", "primary_slot_name": "readonly_cluster"}, actual)
+		assert.Equal(t, expected, actual)
 	})
 }
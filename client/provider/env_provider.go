@@ -36,7 +36,7 @@ func (provider DefaultEnvProvider) Envs() (EnvsFiles, error) {
 
 	envErrors := file.Errors{}
 	for _, yamlFile := range yamlFiles {
-		ok, err := yamlFile.Check(stevedore.KindStevedoreEnv, stevedore.EnvCurrentVersion)
+		ok, err := yamlFile.Check(stevedore.KindStevedoreEnv, stevedore.SupportedVersions(stevedore.KindStevedoreEnv)...)
 		if err != nil {
 			envErrors = append(envErrors, file.Error{Filename: yamlFile.Name, Reason: err})
 			continue
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	pkgConfig "github.com/gojek/stevedore/pkg/config"
+	"github.com/gojek/stevedore/pkg/plugin"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	defaultPlugins["file"] = ClientPlugin{PluginImpl: FileConfigProvider{fs: afero.NewOsFs()}}
+}
+
+// FileConfigProvider is the struct represents the ConfigProvider for the local yaml, json, dotenv and text files
+type FileConfigProvider struct {
+	fs afero.Fs
+	// manifestDir is the directory which the relative paths are resolved against
+	manifestDir string
+}
+
+var _ pkgConfig.Provider = FileConfigProvider{}
+var _ pkgConfig.ManifestRelativeProvider = FileConfigProvider{}
+var _ pkgConfig.SensitiveKeysProvider = FileConfigProvider{}
+var _ plugin.ConfigInterface = FileConfigProvider{}
+
+// Formats of the files supported by the FileConfigProvider
+const (
+	FileFormatYAML = "yaml"
+	FileFormatJSON = "json"
+	FileFormatEnv  = "env"
+	FileFormatText = "text"
+)
+
+// ConfigFile represents a file or a directory of files to read the configs from
+type ConfigFile struct {
+	// Path of the file or directory, relative to the manifest unless absolute, eg: secrets/${environment}/db.yaml
+	Path string
+	// Format of the file, which is inferred from its extension unless given
+	Format string
+	// Key of the value of a text file, which defaults to the name of the file without its extension.
+	// Values of the other formats are nested under it, if given
+	Key string
+	// Optional files are skipped when they do not exist
+	Optional bool
+	// Sensitive values are redacted when displayed and are never cached on disk
+	Sensitive bool
+}
+
+// RelativeTo returns the provider which reads the files relative to the directory of the manifest
+func (p FileConfigProvider) RelativeTo(manifestDir string) pkgConfig.Provider {
+	return FileConfigProvider{fs: p.fs, manifestDir: manifestDir}
+}
+
+// Fetch configuration from the files
+func (p FileConfigProvider) Fetch(
+	context map[string]string,
+	data interface{},
+) (map[string]interface{}, error) {
+	configs, _, err := p.FetchSensitiveKeys(context, data)
+	return configs, err
+}
+
+// FetchSensitiveKeys fetches the configuration from the files, along with the keys read from the sensitive files.
+// A key is sensitive if its value is read from a sensitive file, even if an earlier file has the same key
+func (p FileConfigProvider) FetchSensitiveKeys(
+	context map[string]string,
+	data interface{},
+) (map[string]interface{}, []string, error) {
+	var files []ConfigFile
+	if err := mapstructure.Decode(data, &files); err != nil {
+		return nil, nil, fmt.Errorf("invalid file configs: %v", err)
+	}
+
+	variables := stevedore.Substitute{}
+	for key, value := range context {
+		variables[key] = value
+	}
+
+	finalConfigs := map[string]interface{}{}
+	sensitive := map[string]bool{}
+	for _, configFile := range files {
+		path, err := variables.Interpolate(configFile.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to resolve the path %s: %v", configFile.Path, err)
+		}
+		if !filepath.IsAbs(path) && p.manifestDir != "" {
+			path = filepath.Join(p.manifestDir, path)
+		}
+
+		values, err := p.read(path, configFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range values {
+			finalConfigs[key] = value
+			sensitive[key] = configFile.Sensitive
+		}
+	}
+
+	var sensitiveKeys []string
+	for key, isSensitive := range sensitive {
+		if isSensitive {
+			sensitiveKeys = append(sensitiveKeys, key)
+		}
+	}
+	sort.Strings(sensitiveKeys)
+	return finalConfigs, sensitiveKeys, nil
+}
+
+// read returns the values of the file, or of each of the files in the directory in the order of their names
+func (p FileConfigProvider) read(path string, configFile ConfigFile) (map[string]interface{}, error) {
+	info, err := p.fs.Stat(path)
+	if os.IsNotExist(err) && configFile.Optional {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", path, err)
+	}
+	if !info.IsDir() {
+		return p.readFile(path, configFile.Format, configFile.Key)
+	}
+
+	entries, err := afero.ReadDir(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", path, err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	result := map[string]interface{}{}
+	for _, entry := range entries {
+		if entry.IsDir() || (strings.HasPrefix(entry.Name(), ".") && entry.Name() != ".env") {
+			continue
+		}
+		values, err := p.readFile(filepath.Join(path, entry.Name()), configFile.Format, "")
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			result[key] = value
+		}
+	}
+	if configFile.Key != "" {
+		return map[string]interface{}{configFile.Key: result}, nil
+	}
+	return result, nil
+}
+
+func (p FileConfigProvider) readFile(path, format, key string) (map[string]interface{}, error) {
+	content, err := afero.ReadFile(p.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", path, err)
+	}
+
+	if format == "" {
+		format = fileFormat(path)
+	}
+
+	var values map[string]interface{}
+	switch format {
+	case FileFormatYAML:
+		err = yaml.Unmarshal(content, &values)
+	case FileFormatJSON:
+		err = json.Unmarshal(content, &values)
+	case FileFormatEnv:
+		values, err = parseDotEnv(string(content))
+	case FileFormatText:
+		if key == "" {
+			key = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		return map[string]interface{}{key: strings.TrimRight(string(content), "\r\n")}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %s for %s, it should be one of %s, %s, %s or %s",
+			format, path, FileFormatYAML, FileFormatJSON, FileFormatEnv, FileFormatText)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s as %s: %v", path, format, err)
+	}
+
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if key != "" {
+		return map[string]interface{}{key: values}, nil
+	}
+	return values, nil
+}
+
+// fileFormat returns the format of the file based on its extension, which defaults to text
+func fileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FileFormatYAML
+	case ".json":
+		return FileFormatJSON
+	case ".env":
+		return FileFormatEnv
+	}
+	if filepath.Base(path) == ".env" {
+		return FileFormatEnv
+	}
+	return FileFormatText
+}
+
+// parseDotEnv parses the KEY=VALUE lines of the dotenv content,
+// ignoring the blank lines, comments and the export prefix
+func parseDotEnv(content string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for index, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		parts := strings.SplitN(line, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("invalid line %d, it should be of the form KEY=VALUE", index+1)
+		}
+
+		value := strings.TrimSpace(parts[1])
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		} else if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = value[1 : len(value)-1]
+		} else if comment := strings.Index(value, " #"); comment >= 0 {
+			value = strings.TrimSpace(value[:comment])
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// Init the FileConfigProvider
+func (FileConfigProvider) Init() error {
+	return nil
+}
+
+// Version of the FileConfigProvider
+func (FileConfigProvider) Version() (string, error) {
+	return "v0.0.1", nil
+}
+
+// Flags for the File ConfigProvider
+func (FileConfigProvider) Flags() ([]plugin.Flag, error) {
+	return []plugin.Flag{}, nil
+}
+
+// Type of the Provider. FileConfigProvider is of type Config
+func (FileConfigProvider) Type() (plugin.Type, error) {
+	return plugin.TypeConfig, nil
+}
+
+// Help returns the help message for the plugin
+func (FileConfigProvider) Help() (string, error) {
+	return `Reads configs from the yaml, json, dotenv or text files, or the directories of them,
+relative to the manifest, eg:
+configs:
+  file:
+    - path: secrets/${environment}/db.yaml
+      sensitive: true
+    - path: .env
+      optional: true
+    - path: secrets/api-token.txt
+      key: API_TOKEN
+      sensitive: true`, nil
+}
+
+// Close the plugin
+func (FileConfigProvider) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileConfigProviderFetch(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(memFs, "/app/manifests/secrets/staging/db.yaml", []byte("DB_HOST: db.staging\nDB_PORT: 5432\n"), 0644)
+	_ = afero.WriteFile(memFs, "/app/manifests/secrets/staging/sentry.json", []byte(`{"SENTRY_DSN": "dsn"}`), 0644)
+	_ = afero.WriteFile(memFs, "/app/manifests/.env", []byte("# local\nexport API_KEY=key\nDB_USER=\"stevedore\"\nDB_NAME='orders' \nREGION=in # india\n"), 0644)
+	_ = afero.WriteFile(memFs, "/app/manifests/secrets/token.txt", []byte("token\n"), 0644)
+	_ = afero.WriteFile(memFs, "/app/manifests/mounted/DB_PASSWORD", []byte("password\n"), 0644)
+	_ = afero.WriteFile(memFs, "/app/manifests/mounted/.gitkeep", []byte(""), 0644)
+	_ = afero.WriteFile(memFs, "/etc/stevedore/common.yml", []byte("LOG_LEVEL: info\n"), 0644)
+	provider := FileConfigProvider{fs: memFs}.RelativeTo("/app/manifests")
+	context := map[string]string{"environment": "staging"}
+
+	t.Run("should read the files relative to the manifest with the paths templated by context", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": "secrets/${environment}/db.yaml"},
+			map[interface{}]interface{}{"path": "secrets/${environment}/sentry.json"},
+			map[interface{}]interface{}{"path": ".env"},
+			map[interface{}]interface{}{"path": "secrets/token.txt", "key": "API_TOKEN"},
+			map[interface{}]interface{}{"path": "/etc/stevedore/common.yml"},
+		}
+
+		actual, err := provider.Fetch(context, data)
+
+		expected := map[string]interface{}{
+			"DB_HOST":    "db.staging",
+			"DB_PORT":    5432,
+			"SENTRY_DSN": "dsn",
+			"API_KEY":    "key",
+			"DB_USER":    "stevedore",
+			"DB_NAME":    "orders",
+			"REGION":     "in",
+			"API_TOKEN":  "token",
+			"LOG_LEVEL":  "info",
+		}
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("should read each file of the directory keyed by its name", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": "mounted"},
+			map[interface{}]interface{}{"path": "secrets/staging", "key": "staging"},
+		}
+
+		actual, err := provider.Fetch(context, data)
+
+		expected := map[string]interface{}{
+			"DB_PASSWORD": "password",
+			"staging":     map[string]interface{}{"DB_HOST": "db.staging", "DB_PORT": 5432, "SENTRY_DSN": "dsn"},
+		}
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("should return the keys read from the sensitive files", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": "secrets/${environment}/db.yaml"},
+			map[interface{}]interface{}{"path": "mounted", "sensitive": true},
+			map[interface{}]interface{}{"path": "secrets/token.txt", "key": "API_TOKEN", "sensitive": true},
+			map[interface{}]interface{}{"path": ".env"},
+		}
+
+		actual, sensitiveKeys, err := provider.(FileConfigProvider).FetchSensitiveKeys(context, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "password", actual["DB_PASSWORD"])
+			assert.Equal(t, []string{"API_TOKEN", "DB_PASSWORD"}, sensitiveKeys)
+		}
+	})
+
+	t.Run("should not return the keys of sensitive files overridden by the later files", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": ".env", "sensitive": true},
+			map[interface{}]interface{}{"path": "secrets/${environment}/db.yaml", "sensitive": true},
+			map[interface{}]interface{}{"path": "/etc/stevedore/common.yml"},
+			map[interface{}]interface{}{"path": "secrets/token.txt", "key": "API_KEY"},
+		}
+
+		_, sensitiveKeys, err := provider.(FileConfigProvider).FetchSensitiveKeys(context, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"DB_HOST", "DB_NAME", "DB_PORT", "DB_USER", "REGION"}, sensitiveKeys)
+		}
+	})
+
+	t.Run("should skip the optional files which do not exist", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": "secrets/${environment}/missing.yaml", "optional": true},
+		}
+
+		actual, err := provider.Fetch(context, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{}, actual)
+		}
+	})
+
+	t.Run("should return error if the file does not exist", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secrets/production/db.yaml"}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.EqualError(t, err, "unable to read /app/manifests/secrets/production/db.yaml: open /app/manifests/secrets/production/db.yaml: file does not exist")
+	})
+
+	t.Run("should return error if the path refers to an unknown variable", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secrets/${team}/db.yaml"}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error if the dotenv file is invalid", func(t *testing.T) {
+		_ = afero.WriteFile(memFs, "/app/manifests/invalid.env", []byte("API_KEY=key\nDB_PASSWORD\n"), 0644)
+		data := []interface{}{map[interface{}]interface{}{"path": "invalid.env"}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.EqualError(t, err, "unable to parse /app/manifests/invalid.env as env: invalid line 2, it should be of the form KEY=VALUE")
+	})
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gojek/stevedore/cmd/kubeconfig"
+	pkgConfig "github.com/gojek/stevedore/pkg/config"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/plugin"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/afero"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func init() {
+	defaultPlugins["kubernetes"] = ClientPlugin{PluginImpl: KubernetesConfigProvider{
+		fs:      afero.NewOsFs(),
+		homeDir: kubeconfig.OSHomeDirResolver,
+		connect: connectCluster,
+	}}
+}
+
+// KubernetesConfigProvider is the struct represents the ConfigProvider for the Secrets and ConfigMaps
+// of the kubernetes cluster of the stevedore context
+type KubernetesConfigProvider struct {
+	fs      afero.Fs
+	homeDir kubeconfig.HomeDirResolver
+	connect func(options helm.KubeOptions) (kubernetesObjects, error)
+	// kubeOptions are the options of the helm client, such as the bearer token and the api server
+	kubeOptions helm.KubeOptions
+}
+
+// WithKubeOptions returns the providers in which the KubernetesConfigProvider connects to the cluster
+// using the given options of the helm client, unless the kubeconfig is given by its flag
+func WithKubeOptions(providers pkgConfig.Providers, options helm.KubeOptions) pkgConfig.Providers {
+	result := make(pkgConfig.Providers, 0, len(providers))
+	for _, each := range providers {
+		if kubernetesProvider, ok := each.Provider.(KubernetesConfigProvider); ok {
+			kubernetesProvider.kubeOptions = options
+			each.Provider = kubernetesProvider
+		}
+		result = append(result, each)
+	}
+	return result
+}
+
+var _ pkgConfig.Provider = KubernetesConfigProvider{}
+var _ pkgConfig.SensitiveKeysProvider = KubernetesConfigProvider{}
+var _ plugin.ConfigInterface = KubernetesConfigProvider{}
+
+const (
+	kubernetesKubeconfigFlag = "kubeconfig"
+	kubernetesNamespaceFlag  = "namespace"
+)
+
+// Kinds of the kubernetes objects supported by the KubernetesConfigProvider
+const (
+	KindSecret    = "Secret"
+	KindConfigMap = "ConfigMap"
+)
+
+// KubernetesObject represents a Secret or ConfigMap to be read from the cluster
+type KubernetesObject struct {
+	// Kind of the object, either Secret or ConfigMap
+	Kind string
+	// Name of the object
+	Name string
+	// Namespace of the object, which defaults to the namespace flag
+	Namespace string
+	// Keys to be selected from the data of the object, all the keys are selected if empty
+	Keys []string
+}
+
+// kubernetesObjects reads the data of the Secrets and ConfigMaps of a cluster
+type kubernetesObjects interface {
+	Secret(namespace, name string) (map[string][]byte, error)
+	ConfigMap(namespace, name string) (map[string]string, error)
+}
+
+// Fetch configuration from the Secrets and ConfigMaps
+func (p KubernetesConfigProvider) Fetch(
+	context map[string]string,
+	data interface{},
+) (map[string]interface{}, error) {
+	configs, _, err := p.FetchSensitiveKeys(context, data)
+	return configs, err
+}
+
+// FetchSensitiveKeys fetches the configuration from the Secrets and ConfigMaps, along with the keys read from the Secrets
+func (p KubernetesConfigProvider) FetchSensitiveKeys(
+	context map[string]string,
+	data interface{},
+) (map[string]interface{}, []string, error) {
+	var objects []KubernetesObject
+	if err := mapstructure.Decode(data, &objects); err != nil {
+		return nil, nil, fmt.Errorf("invalid kubernetes configs: %v", err)
+	}
+
+	stevedoreContext := stevedore.Context{
+		KubernetesContext: context["kubernetesContext"],
+		KubeConfigFile:    context["kubeConfigFile"],
+	}
+	kubeconfigFlag := context[kubernetesKubeconfigFlag]
+	if kubeconfigFlag == "" {
+		kubeconfigFlag = p.kubeOptions.KubeConfig
+	}
+	kubeconfigPath, err := kubeconfig.ResolveAndValidate(p.homeDir, kubeconfigFlag, p.fs, stevedoreContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options := p.kubeOptions
+	options.KubeConfig = kubeconfigPath
+	options.KubeContext = stevedoreContext.KubernetesContext
+	cluster, err := p.connect(options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to connect to kubernetes context %s: %v", stevedoreContext.KubernetesContext, err)
+	}
+
+	defaultNamespace := context[kubernetesNamespaceFlag]
+	if defaultNamespace == "" {
+		defaultNamespace = metav1.NamespaceDefault
+	}
+
+	finalConfigs := map[string]interface{}{}
+	secretKeys := map[string]bool{}
+	for _, object := range objects {
+		if object.Namespace == "" {
+			object.Namespace = defaultNamespace
+		}
+		values, err := p.read(cluster, object)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range values {
+			finalConfigs[key] = value
+			secretKeys[key] = strings.EqualFold(object.Kind, KindSecret)
+		}
+	}
+
+	var sensitiveKeys []string
+	for key, secret := range secretKeys {
+		if secret {
+			sensitiveKeys = append(sensitiveKeys, key)
+		}
+	}
+	sort.Strings(sensitiveKeys)
+	return finalConfigs, sensitiveKeys, nil
+}
+
+// read returns the selected keys of the data of the object
+func (p KubernetesConfigProvider) read(cluster kubernetesObjects, object KubernetesObject) (map[string]interface{}, error) {
+	if object.Name == "" {
+		return nil, fmt.Errorf("name of the %s is not set", object.Kind)
+	}
+
+	values := map[string]interface{}{}
+	switch {
+	case strings.EqualFold(object.Kind, KindSecret):
+		data, err := cluster.Secret(object.Namespace, object.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read secret %s/%s: %v", object.Namespace, object.Name, err)
+		}
+		for key, value := range data {
+			values[key] = string(value)
+		}
+	case strings.EqualFold(object.Kind, KindConfigMap):
+		data, err := cluster.ConfigMap(object.Namespace, object.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read configmap %s/%s: %v", object.Namespace, object.Name, err)
+		}
+		for key, value := range data {
+			values[key] = value
+		}
+	default:
+		return nil, fmt.Errorf("invalid kind %s for %s, it should be either %s or %s", object.Kind, object.Name, KindSecret, KindConfigMap)
+	}
+
+	if len(object.Keys) == 0 {
+		return values, nil
+	}
+
+	selected := make(map[string]interface{}, len(object.Keys))
+	for _, key := range object.Keys {
+		value, ok := values[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in %s %s/%s", key, strings.ToLower(object.Kind), object.Namespace, object.Name)
+		}
+		selected[key] = value
+	}
+	return selected, nil
+}
+
+// clusterObjects reads the Secrets and ConfigMaps using the kubernetes api
+type clusterObjects struct {
+	core corev1.CoreV1Interface
+}
+
+func connectCluster(options helm.KubeOptions) (kubernetesObjects, error) {
+	restConfig, err := options.ConfigFlags(metav1.NamespaceDefault).ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return clusterObjects{core: clientSet.CoreV1()}, nil
+}
+
+func (cluster clusterObjects) Secret(namespace, name string) (map[string][]byte, error) {
+	secret, err := cluster.core.Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+func (cluster clusterObjects) ConfigMap(namespace, name string) (map[string]string, error) {
+	configMap, err := cluster.core.ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return configMap.Data, nil
+}
+
+// Init the KubernetesConfigProvider
+func (KubernetesConfigProvider) Init() error {
+	return nil
+}
+
+// Version of the KubernetesConfigProvider
+func (KubernetesConfigProvider) Version() (string, error) {
+	return "v0.0.1", nil
+}
+
+// Flags for the Kubernetes ConfigProvider
+func (KubernetesConfigProvider) Flags() ([]plugin.Flag, error) {
+	return []plugin.Flag{
+		{Name: kubernetesKubeconfigFlag, Usage: "path to kubeconfig file (default is resolved for the stevedore context)"},
+		{Name: kubernetesNamespaceFlag, Default: metav1.NamespaceDefault, Usage: "namespace of the objects, unless given for the object"},
+	}, nil
+}
+
+// Type of the Provider. KubernetesConfigProvider is of type Config
+func (KubernetesConfigProvider) Type() (plugin.Type, error) {
+	return plugin.TypeConfig, nil
+}
+
+// Help returns the help message for the plugin
+func (KubernetesConfigProvider) Help() (string, error) {
+	return `Reads the Secrets and ConfigMaps of the kubernetes cluster of the stevedore context, eg:
+configs:
+  kubernetes:
+    - kind: Secret
+      name: postgres-credentials
+      namespace: data
+      keys: [DB_PASSWORD]
+    - kind: ConfigMap
+      name: x-service`, nil
+}
+
+// Close the plugin
+func (KubernetesConfigProvider) Close() error {
+	return nil
+}
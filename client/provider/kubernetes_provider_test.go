@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/config"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// inMemoryCluster is a stand-in for the Secrets and ConfigMaps of a cluster, keyed by namespace/name
+type inMemoryCluster struct {
+	secrets    map[string]map[string][]byte
+	configMaps map[string]map[string]string
+}
+
+func (cluster inMemoryCluster) Secret(namespace, name string) (map[string][]byte, error) {
+	if data, ok := cluster.secrets[namespace+"/"+name]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (cluster inMemoryCluster) ConfigMap(namespace, name string) (map[string]string, error) {
+	if data, ok := cluster.configMaps[namespace+"/"+name]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func TestKubernetesConfigProviderFetch(t *testing.T) {
+	cluster := inMemoryCluster{
+		secrets: map[string]map[string][]byte{
+			"data/postgres-credentials": {"DB_USER": []byte("stevedore"), "DB_PASSWORD": []byte("password")},
+		},
+		configMaps: map[string]map[string]string{
+			"default/x-service": {"LOG_LEVEL": "info"},
+			"data/postgres":     {"DB_USER": "postgres"},
+		},
+	}
+	memFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(memFs, "/Users/someone/.kube/configs/staging", []byte(""), 0644)
+	_ = afero.WriteFile(memFs, "/mock/kubeconfig", []byte(""), 0644)
+	homeDir := func() (string, error) { return "/Users/someone", nil }
+	if kubeconfigEnv, ok := os.LookupEnv("KUBECONFIG"); ok {
+		_ = os.Unsetenv("KUBECONFIG")
+		defer func() { _ = os.Setenv("KUBECONFIG", kubeconfigEnv) }()
+	}
+
+	var connectedTo helm.KubeOptions
+	provider := KubernetesConfigProvider{
+		fs:      memFs,
+		homeDir: homeDir,
+		connect: func(options helm.KubeOptions) (kubernetesObjects, error) {
+			connectedTo = options
+			return cluster, nil
+		},
+	}
+	context := map[string]string{"kubernetesContext": "staging", "namespace": "default"}
+
+	t.Run("should read secrets and configmaps from the cluster of the context", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"kind": "Secret", "name": "postgres-credentials", "namespace": "data", "keys": []interface{}{"DB_PASSWORD"}},
+			map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service"},
+		}
+
+		actual, sensitiveKeys, err := provider.FetchSensitiveKeys(context, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{"DB_PASSWORD": "password", "LOG_LEVEL": "info"}, actual)
+			assert.Equal(t, []string{"DB_PASSWORD"}, sensitiveKeys)
+			assert.Equal(t, helm.KubeOptions{KubeConfig: "/Users/someone/.kube/configs/staging", KubeContext: "staging"}, connectedTo)
+		}
+	})
+
+	t.Run("should mark the keys as sensitive only if they are read from a secret", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service"},
+			map[interface{}]interface{}{"kind": "Secret", "name": "postgres-credentials", "namespace": "data"},
+			map[interface{}]interface{}{"kind": "ConfigMap", "name": "postgres", "namespace": "data"},
+		}
+
+		actual, sensitiveKeys, err := provider.FetchSensitiveKeys(context, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{"DB_USER": "postgres", "DB_PASSWORD": "password", "LOG_LEVEL": "info"}, actual)
+			assert.Equal(t, []string{"DB_PASSWORD"}, sensitiveKeys)
+		}
+	})
+
+	t.Run("should connect using the options of the helm client", func(t *testing.T) {
+		options := helm.KubeOptions{KubeConfig: "/mock/kubeconfig", KubeContext: "components", BearerToken: "token", APIServer: "https://10.0.0.1:6443"}
+		providers := WithKubeOptions(config.Providers{{Name: "kubernetes", Provider: provider}}, options)
+		data := map[string]interface{}{"kubernetes": []interface{}{map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service"}}}
+
+		_, err := providers.Fetch(map[string]string{"kubernetesContext": "staging"}, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, helm.KubeOptions{KubeConfig: "/mock/kubeconfig", KubeContext: "staging", BearerToken: "token", APIServer: "https://10.0.0.1:6443"}, connectedTo)
+		}
+	})
+
+	t.Run("should use the kubeconfig given by the flag", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service"}}
+
+		_, err := provider.Fetch(map[string]string{"kubernetesContext": "staging", "kubeconfig": "/mock/kubeconfig"}, data)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, helm.KubeOptions{KubeConfig: "/mock/kubeconfig", KubeContext: "staging"}, connectedTo)
+		}
+	})
+
+	t.Run("should return error if the object does not exist", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"kind": "Secret", "name": "redis-credentials"}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.EqualError(t, err, "unable to read secret default/redis-credentials: not found")
+	})
+
+	t.Run("should return error if the key does not exist", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service", "keys": []interface{}{"PORT"}}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.EqualError(t, err, "key PORT not found in configmap default/x-service")
+	})
+
+	t.Run("should return error if the kind is invalid", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"kind": "Deployment", "name": "x-service"}}
+
+		_, err := provider.Fetch(context, data)
+
+		assert.EqualError(t, err, "invalid kind Deployment for x-service, it should be either Secret or ConfigMap")
+	})
+
+	t.Run("should return error if the kubeconfig can not be found", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"kind": "ConfigMap", "name": "x-service"}}
+
+		_, err := provider.Fetch(map[string]string{"kubernetesContext": "production"}, data)
+
+		assert.EqualError(t, err, "unable to find kubeconfig file /Users/someone/.kube/config due to open /Users/someone/.kube/config: file does not exist")
+	})
+}
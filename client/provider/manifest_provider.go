@@ -184,7 +184,7 @@ func getAllManifestFiles(fs afero.Fs, dirPath string) (stevedore.ManifestFiles,
 	var fileErrors file.Errors
 	manifests := stevedore.ManifestFiles{}
 	for _, yamlFile := range yamlFiles {
-		ok, err := yamlFile.Check(stevedore.KindStevedoreManifest, stevedore.ManifestCurrentVersion)
+		ok, err := yamlFile.Check(stevedore.KindStevedoreManifest, stevedore.SupportedVersions(stevedore.KindStevedoreManifest)...)
 		if err != nil {
 			fileErrors = append(fileErrors, file.Error{Filename: yamlFile.Name, Reason: err})
 			continue
@@ -453,7 +453,7 @@ spec:
 			assert.Equal(t, "/mock/services/service-one.yaml", manifests[0].File)
 			assert.Equal(t, stevedore.Matchers{{stevedore.ConditionContextName: "services"}}, manifests[0].DeployTo)
 
-			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{})
+			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{}, stevedore.Provenance{}, stevedore.Sources{})
 			if !cmp.Equal(expected, actual, ignoreTypes) {
 				assert.Fail(t, cmp.Diff(expected, actual, ignoreTypes))
 			}
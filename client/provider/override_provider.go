@@ -36,7 +36,7 @@ func (provider DefaultOverrideProvider) Overrides() (stevedore.Overrides, error)
 	overrideErrors := file.Errors{}
 	specs := stevedore.OverrideSpecifications{}
 	for _, yamlFile := range yamlFiles {
-		ok, err := yamlFile.Check(stevedore.KindStevedoreOverride, stevedore.OverrideCurrentVersion)
+		ok, err := yamlFile.Check(stevedore.KindStevedoreOverride, stevedore.SupportedVersions(stevedore.KindStevedoreOverride)...)
 		if err != nil {
 			overrideErrors = append(overrideErrors, file.Error{Filename: yamlFile.Name, Reason: err})
 			continue
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgConfig "github.com/gojek/stevedore/pkg/config"
+	"github.com/gojek/stevedore/pkg/plugin"
+	"github.com/mitchellh/mapstructure"
+)
+
+func init() {
+	defaultPlugins["vault"] = ClientPlugin{PluginImpl: VaultConfigProvider{client: &http.Client{Timeout: 30 * time.Second}}}
+}
+
+// VaultConfigProvider is the struct represents the ConfigProvider for the KV secrets engine of HashiCorp Vault
+type VaultConfigProvider struct {
+	client *http.Client
+}
+
+var _ pkgConfig.Provider = VaultConfigProvider{}
+var _ pkgConfig.SensitiveProvider = VaultConfigProvider{}
+var _ plugin.ConfigInterface = VaultConfigProvider{}
+
+const (
+	vaultAddressFlag     = "address"
+	vaultTokenFlag       = "token"
+	vaultRoleIDFlag      = "role-id"
+	vaultSecretIDFlag    = "secret-id"
+	vaultAppRolePathFlag = "approle-path"
+	vaultNamespaceFlag   = "namespace"
+	vaultKVVersionFlag   = "kv-version"
+
+	vaultTokenEnv = "VAULT_TOKEN"
+)
+
+// VaultSecret represents a secret to be fetched from vault
+type VaultSecret struct {
+	// Path of the secret, including the mount of the secrets engine unless Mount is given, eg: secret/x-service
+	Path string
+	// Mount of the secrets engine
+	Mount string
+	// Version of the KV secrets engine, which defaults to the kv-version flag
+	Version int
+	// Fields to be selected from the secret, all the fields are selected if empty
+	Fields []string
+}
+
+// Fetch configuration from vault
+func (p VaultConfigProvider) Fetch(
+	context map[string]string,
+	data interface{},
+) (map[string]interface{}, error) {
+	address := strings.TrimSuffix(context[vaultAddressFlag], "/")
+	if address == "" {
+		return nil, fmt.Errorf("%s not set", vaultAddressFlag)
+	}
+
+	defaultVersion := 2
+	if version, ok := context[vaultKVVersionFlag]; ok && version != "" {
+		parsed, err := strconv.Atoi(version)
+		if err != nil || (parsed != 1 && parsed != 2) {
+			return nil, fmt.Errorf("invalid value for %s: %s, it should be either 1 or 2", vaultKVVersionFlag, version)
+		}
+		defaultVersion = parsed
+	}
+
+	var secrets []VaultSecret
+	if err := mapstructure.Decode(data, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid vault configs: %v", err)
+	}
+
+	token, err := p.token(address, context)
+	if err != nil {
+		return nil, err
+	}
+
+	finalConfigs := map[string]interface{}{}
+	for _, secret := range secrets {
+		if secret.Version == 0 {
+			secret.Version = defaultVersion
+		}
+		values, err := p.read(address, token, context[vaultNamespaceFlag], secret)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			finalConfigs[key] = value
+		}
+	}
+	return finalConfigs, nil
+}
+
+// token returns the token given by the flag or the environment, else logs in using AppRole
+func (p VaultConfigProvider) token(address string, context map[string]string) (string, error) {
+	if token := context[vaultTokenFlag]; token != "" {
+		return token, nil
+	}
+	if token := os.Getenv(vaultTokenEnv); token != "" {
+		return token, nil
+	}
+
+	roleID := context[vaultRoleIDFlag]
+	if roleID == "" {
+		return "", fmt.Errorf("neither %s nor %s set", vaultTokenFlag, vaultRoleIDFlag)
+	}
+
+	appRolePath := strings.Trim(context[vaultAppRolePathFlag], "/")
+	if appRolePath == "" {
+		appRolePath = "approle"
+	}
+
+	body, err := json.Marshal(map[string]string{"role_id": roleID, "secret_id": context[vaultSecretIDFlag]})
+	if err != nil {
+		return "", err
+	}
+
+	response := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+	url := fmt.Sprintf("%s/v1/auth/%s/login", address, appRolePath)
+	if err := p.do(http.MethodPost, url, "", context[vaultNamespaceFlag], body, &response); err != nil {
+		return "", fmt.Errorf("unable to login to vault using approle: %v", err)
+	}
+	if response.Auth.ClientToken == "" {
+		return "", fmt.Errorf("unable to login to vault using approle: no token returned")
+	}
+	return response.Auth.ClientToken, nil
+}
+
+// read returns the selected fields of the secret
+func (p VaultConfigProvider) read(address, token, namespace string, secret VaultSecret) (map[string]interface{}, error) {
+	path := strings.Trim(secret.Path, "/")
+	mount := strings.Trim(secret.Mount, "/")
+	if mount == "" {
+		parts := strings.SplitN(path, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid vault path %s, it should be prefixed with the mount of the secrets engine", secret.Path)
+		}
+		mount, path = parts[0], parts[1]
+	}
+
+	var values map[string]interface{}
+	switch secret.Version {
+	case 1:
+		response := struct {
+			Data map[string]interface{} `json:"data"`
+		}{}
+		if err := p.do(http.MethodGet, fmt.Sprintf("%s/v1/%s/%s", address, mount, path), token, namespace, nil, &response); err != nil {
+			return nil, fmt.Errorf("unable to read secret %s from vault: %v", secret.Path, err)
+		}
+		values = response.Data
+	case 2:
+		response := struct {
+			Data struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"data"`
+		}{}
+		if err := p.do(http.MethodGet, fmt.Sprintf("%s/v1/%s/data/%s", address, mount, path), token, namespace, nil, &response); err != nil {
+			return nil, fmt.Errorf("unable to read secret %s from vault: %v", secret.Path, err)
+		}
+		values = response.Data.Data
+	default:
+		return nil, fmt.Errorf("invalid kv version %d for secret %s, it should be either 1 or 2", secret.Version, secret.Path)
+	}
+
+	if len(secret.Fields) == 0 {
+		return values, nil
+	}
+
+	selected := make(map[string]interface{}, len(secret.Fields))
+	for _, field := range secret.Fields {
+		value, ok := values[field]
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in secret %s", field, secret.Path)
+		}
+		selected[field] = value
+	}
+	return selected, nil
+}
+
+func (p VaultConfigProvider) do(method, url, token, namespace string, body []byte, result interface{}) error {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+	if namespace != "" {
+		request.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("not found")
+	}
+	if response.StatusCode != http.StatusOK {
+		failure := struct {
+			Errors []string `json:"errors"`
+		}{}
+		_ = json.NewDecoder(response.Body).Decode(&failure)
+		return fmt.Errorf("status %d: %s", response.StatusCode, strings.Join(failure.Errors, ", "))
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// Sensitive returns true, since the values fetched from vault are secrets
+func (VaultConfigProvider) Sensitive() bool {
+	return true
+}
+
+// Init the VaultConfigProvider
+func (VaultConfigProvider) Init() error {
+	return nil
+}
+
+// Version of the VaultConfigProvider
+func (VaultConfigProvider) Version() (string, error) {
+	return "v0.0.1", nil
+}
+
+// Flags for the Vault ConfigProvider
+func (VaultConfigProvider) Flags() ([]plugin.Flag, error) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		address = "http://127.0.0.1:8200"
+	}
+	return []plugin.Flag{
+		{Name: vaultAddressFlag, Default: address, Usage: "address of vault (default from VAULT_ADDR)"},
+		{Name: vaultTokenFlag, Usage: fmt.Sprintf("token to authenticate with vault (default from %s)", vaultTokenEnv)},
+		{Name: vaultRoleIDFlag, Usage: "role id to authenticate with vault using approle, when token is not set"},
+		{Name: vaultSecretIDFlag, Usage: "secret id to authenticate with vault using approle"},
+		{Name: vaultAppRolePathFlag, Default: "approle", Usage: "path at which the approle auth method is enabled"},
+		{Name: vaultNamespaceFlag, Usage: "vault enterprise namespace"},
+		{Name: vaultKVVersionFlag, Default: "2", Usage: "version of the kv secrets engine (1 or 2), unless given for the secret"},
+	}, nil
+}
+
+// Type of the Provider. VaultConfigProvider is of type Config
+func (VaultConfigProvider) Type() (plugin.Type, error) {
+	return plugin.TypeConfig, nil
+}
+
+// Help returns the help message for the plugin
+func (VaultConfigProvider) Help() (string, error) {
+	return `Fetches secrets from the kv secrets engine of vault, eg:
+configs:
+  vault:
+    - path: secret/x-service
+      fields: [DB_PASSWORD]
+    - path: x-service/common
+      mount: kv
+      version: 1`, nil
+}
+
+// Close the plugin
+func (VaultConfigProvider) Close() error {
+	return nil
+}
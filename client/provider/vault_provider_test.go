@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inMemoryVault is a stand-in for vault serving the kv secrets engine v1 at kv1 and v2 at secret
+type inMemoryVault struct {
+	token    string
+	roleID   string
+	secretID string
+	secrets  map[string]map[string]interface{}
+}
+
+func (vault inMemoryVault) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	path := strings.TrimPrefix(request.URL.Path, "/v1/")
+	if path == "auth/approle/login" {
+		credentials := map[string]string{}
+		_ = json.NewDecoder(request.Body).Decode(&credentials)
+		if credentials["role_id"] != vault.roleID || credentials["secret_id"] != vault.secretID {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{"auth": map[string]interface{}{"client_token": vault.token}})
+		return
+	}
+
+	if request.Header.Get("X-Vault-Token") != vault.token {
+		writer.WriteHeader(http.StatusForbidden)
+		_, _ = writer.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	if strings.HasPrefix(path, "secret/data/") {
+		if secret, ok := vault.secrets["secret/"+strings.TrimPrefix(path, "secret/data/")]; ok {
+			_ = json.NewEncoder(writer).Encode(map[string]interface{}{"data": map[string]interface{}{"data": secret, "metadata": map[string]interface{}{"version": 1}}})
+			return
+		}
+	} else if secret, ok := vault.secrets[path]; ok && strings.HasPrefix(path, "kv1/") {
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{"data": secret})
+		return
+	}
+	writer.WriteHeader(http.StatusNotFound)
+	_, _ = writer.Write([]byte(`{"errors":[]}`))
+}
+
+func TestVaultConfigProviderFetch(t *testing.T) {
+	vault := inMemoryVault{
+		token:    "root",
+		roleID:   "role",
+		secretID: "secret",
+		secrets: map[string]map[string]interface{}{
+			"secret/x-service": {"DB_PASSWORD": "password", "API_KEY": "key"},
+			"kv1/common":       {"SENTRY_DSN": "dsn"},
+		},
+	}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+	if token, ok := os.LookupEnv("VAULT_TOKEN"); ok {
+		_ = os.Unsetenv("VAULT_TOKEN")
+		defer func() { _ = os.Setenv("VAULT_TOKEN", token) }()
+	}
+	provider := VaultConfigProvider{client: server.Client()}
+
+	t.Run("should fetch secrets of kv v1 and v2 using token", func(t *testing.T) {
+		data := []interface{}{
+			map[interface{}]interface{}{"path": "secret/x-service"},
+			map[interface{}]interface{}{"path": "common", "mount": "kv1", "version": 1},
+		}
+
+		actual, err := provider.Fetch(map[string]string{"address": server.URL, "token": "root", "kv-version": "2"}, data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"DB_PASSWORD": "password", "API_KEY": "key", "SENTRY_DSN": "dsn"}, actual)
+	})
+
+	t.Run("should fetch the selected fields using approle", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secret/x-service", "fields": []interface{}{"DB_PASSWORD"}}}
+
+		actual, err := provider.Fetch(map[string]string{"address": server.URL, "role-id": "role", "secret-id": "secret"}, data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"DB_PASSWORD": "password"}, actual)
+	})
+
+	t.Run("should fail when the selected field is not found", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secret/x-service", "fields": []interface{}{"USERNAME"}}}
+
+		_, err := provider.Fetch(map[string]string{"address": server.URL, "token": "root"}, data)
+
+		assert.EqualError(t, err, "field USERNAME not found in secret secret/x-service")
+	})
+
+	t.Run("should fail when the secret is not found", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secret/y-service"}}
+
+		_, err := provider.Fetch(map[string]string{"address": server.URL, "token": "root"}, data)
+
+		assert.EqualError(t, err, "unable to read secret secret/y-service from vault: not found")
+	})
+
+	t.Run("should fail when the token is not permitted", func(t *testing.T) {
+		data := []interface{}{map[interface{}]interface{}{"path": "secret/x-service"}}
+
+		_, err := provider.Fetch(map[string]string{"address": server.URL, "token": "invalid"}, data)
+
+		assert.EqualError(t, err, "unable to read secret secret/x-service from vault: status 403: permission denied")
+	})
+
+	t.Run("should fail to login using invalid approle", func(t *testing.T) {
+		_, err := provider.Fetch(map[string]string{"address": server.URL, "role-id": "role", "secret-id": "invalid"}, []interface{}{})
+
+		assert.EqualError(t, err, "unable to login to vault using approle: status 400: invalid role or secret ID")
+	})
+
+	t.Run("should fail when neither token nor role id is set", func(t *testing.T) {
+		_, err := provider.Fetch(map[string]string{"address": server.URL}, []interface{}{})
+
+		assert.EqualError(t, err, "neither token nor role-id set")
+	})
+
+	t.Run("should fail for invalid kv version", func(t *testing.T) {
+		_, err := provider.Fetch(map[string]string{"address": server.URL, "token": "root", "kv-version": "3"}, []interface{}{})
+
+		assert.EqualError(t, err, "invalid value for kv-version: 3, it should be either 1 or 2")
+	})
+}
@@ -50,10 +50,10 @@ func (file File) Version() (string, bool) {
 	return kind, ok
 }
 
-// Check checks if the yaml file has the specified kind and version
-// returns true if it matches the kind and specified version
+// Check checks if the yaml file has the specified kind and any of the versions
+// returns true if it matches the kind and any of the specified versions
 // else returns false
-func (file File) Check(kind, version string) (bool, error) {
+func (file File) Check(kind string, versions ...string) (bool, error) {
 	if fileKind, ok := file.Kind(); !ok || fileKind != kind {
 		return false, nil
 	}
@@ -68,12 +68,16 @@ func (file File) Check(kind, version string) (bool, error) {
 		return false, fmt.Errorf("unable to detect version information from file %s", file.Name)
 	}
 
-	constraints, err := semver.NewConstraint(fmt.Sprintf("= %s", version))
-	if err != nil {
-		return false, fmt.Errorf("unable to check version information from file %s", file.Name)
+	for _, version := range versions {
+		constraints, err := semver.NewConstraint(fmt.Sprintf("= %s", version))
+		if err != nil {
+			return false, fmt.Errorf("unable to check version information from file %s", file.Name)
+		}
+		if constraints.Check(semVersion) {
+			return true, nil
+		}
 	}
-
-	return constraints.Check(semVersion), nil
+	return false, nil
 }
 
 // NewYamlFile returns new File
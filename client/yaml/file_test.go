@@ -383,4 +383,22 @@ kind: StevedoreEnv
 		assert.NoError(t, err)
 		assert.False(t, result)
 	})
+
+	t.Run("should return true if the kind matches and version matches any of the supported versions", func(t *testing.T) {
+		memFs := afero.NewMemMapFs()
+		filename := "/mock/file"
+		content := `
+---
+kind: StevedoreManifest
+version: "1"
+`
+		_ = afero.WriteFile(memFs, filename, []byte(content), 0644)
+
+		file, err := yaml.NewYamlFile(memFs, filename)
+		assert.NoError(t, err)
+
+		result, err := file.Check(stevedore.KindStevedoreManifest, "2", "1")
+		assert.NoError(t, err)
+		assert.True(t, result)
+	})
 }
@@ -22,13 +22,23 @@ func init() {
 	yellowColor = color.New(color.FgYellow)
 }
 
-// DieIf invoke os.Exit if there err is not nil
+// ExitCoder is implemented by errors which denote the status code to exit with
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// DieIf invoke os.Exit if there err is not nil.
+// The status code is taken from err if it implements ExitCoder, otherwise it is 1
 func DieIf(err error, exitFunc func()) {
 	if err != nil {
 		if exitFunc != nil {
 			exitFunc()
 		}
-		Fatalf("\nCommand failed: %v", err)
+		code := 1
+		if exitCoder, ok := err.(ExitCoder); ok {
+			code = exitCoder.ExitCode()
+		}
+		exit(code, fmt.Sprintf("\nCommand failed: %v", err))
 	}
 }
 
@@ -102,10 +112,14 @@ func Warn(value ...interface{}) {
 // Fatal print values to os.Stderr in red color
 // and invoke os.Exit with non zero status code
 func Fatal(value ...interface{}) {
+	exit(1, value...)
+}
+
+func exit(code int, value ...interface{}) {
 	stream := os.Stderr
 	_, _ = redColor.Add(color.Bold).Fprint(stream, value...)
 	_, _ = fmt.Fprintf(stream, "\n")
-	os.Exit(1)
+	os.Exit(code)
 }
 
 // Infof format and print values to OutputStream() in white color
@@ -149,8 +163,19 @@ func ColorForced() bool {
 	return false
 }
 
+var outputStream io.Writer
+
+// SetOutputStream overrides the io.Writer given by OutputStream,
+// such as to keep os.Stdout only for the output of a command
+func SetOutputStream(writer io.Writer) {
+	outputStream = writer
+}
+
 // OutputStream gives io.Writer based on interactive terminal
 func OutputStream() io.Writer {
+	if outputStream != nil {
+		return outputStream
+	}
 	if IsTTY() {
 		return os.Stdout
 	}
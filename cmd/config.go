@@ -121,6 +121,7 @@ const (
 	environmentFlag     = "environment"
 	environmentTypeFlag = "environment-type"
 	kubeContextFlag     = "kube-context"
+	labelFlag           = "label"
 )
 
 var addCtxtErrors = map[string]string{
@@ -128,6 +129,7 @@ var addCtxtErrors = map[string]string{
 	"Environment":       fmt.Sprintf("Provide a environment for stevedore context using --%s", environmentFlag),
 	"EnvironmentType":   fmt.Sprintf("Provide a environment type for stevedore context using --%s", environmentTypeFlag),
 	"KubernetesContext": fmt.Sprintf("Provide a kubecontext for stevedore context using --%s", kubeContextFlag),
+	"Labels":            fmt.Sprintf("Provide labels with names consisting of alphanumeric characters, '-', '_' or '.' using --%s", labelFlag),
 }
 
 type contextErrors []string
@@ -180,6 +182,9 @@ var configAddContextCmd = &cobra.Command{
 		table.Append([]string{"Environment", ctx.Environment})
 		table.Append([]string{"Environment Type", ctx.EnvironmentType})
 		table.Append([]string{"Kubernetes Context", ctx.KubernetesContext})
+		if len(ctx.Labels) != 0 {
+			table.Append([]string{"Labels", ctx.Labels.String()})
+		}
 		table.Render()
 
 		err = stevedoreConfig.Use(ctx.Name)
@@ -276,6 +281,7 @@ func init() {
 	configAddContextCmd.PersistentFlags().StringVar(&ctx.Environment, environmentFlag, "", "Environment of the stevedore context")
 	configAddContextCmd.PersistentFlags().StringVar(&ctx.EnvironmentType, environmentTypeFlag, "", "Type of Environment of stevedore context (eg. staging|production)")
 	configAddContextCmd.PersistentFlags().StringVar(&ctx.KubernetesContext, kubeContextFlag, "", "Kubernetes cluster of the stevedore context")
+	configAddContextCmd.PersistentFlags().StringToStringVar((*map[string]string)(&ctx.Labels), labelFlag, nil, "Labels of the stevedore context to match on, as key=value (eg. --label region=eu-west --label team=payments)")
 
 	configCmd.AddCommand(configViewCmd)
 	configCmd.AddCommand(configGetContextsCmd)
@@ -74,3 +74,13 @@ func PluginDirPath() (string, error) {
 
 	return filepath.Join(home, ".config", "stevedore", "plugins"), nil
 }
+
+// CacheDirPath returns path of the directory in which the configs fetched by the config providers are cached
+func CacheDirPath() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find home directory: %v", err)
+	}
+
+	return filepath.Join(home, ".cache", "stevedore", "configs"), nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gojek/stevedore/client/yaml"
+	"github.com/gojek/stevedore/cmd/cli"
 	"github.com/gojek/stevedore/pkg/stevedore"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ var (
 	fmtEnvsPath      string
 	fmtOverridesPath string
 	fmtManifestPath  string
+	fmtMigrate       bool
 )
 
 type formatErrors []string
@@ -30,6 +32,8 @@ func (fmtErrors formatErrors) Error() string {
 
 type fileContent struct {
 	fileName string
+	kind     string
+	version  string
 	content  fmt.Formatter
 }
 
@@ -40,14 +44,15 @@ type readFileContent func(reader io.Reader) (fmt.Formatter, error)
 type contentFetcher func(fs afero.Fs) (fileContents, error)
 
 var fmtCmd = &cobra.Command{
-	Use:           "fmt",
-	Short:         "Format stevedore yaml(s)",
-	Long:          `Format stevedore yaml(s) and save it back`,
+	Use:   "fmt",
+	Short: "Format stevedore yaml(s)",
+	Long: `Format stevedore yaml(s) and save it back.
+Yaml(s) of an older version are left untouched, unless --migrate is specified`,
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var all fileContents
-		fetchers := getFetchers()
+		fetchers := getFetchers(fmtManifestPath, fmtOverridesPath, fmtEnvsPath)
 		for _, fetch := range fetchers {
 			contents, err := fetch(fs)
 			if err != nil {
@@ -55,22 +60,22 @@ var fmtCmd = &cobra.Command{
 			}
 			all = append(all, contents...)
 		}
-		return format(fs, all...)
+		return format(fs, cli.OutputStream(), fmtMigrate, all...)
 	},
 }
 
-func getFetchers() []contentFetcher {
+func getFetchers(manifestPath, overridesPath, envsPath string) []contentFetcher {
 	fetchers := make([]contentFetcher, 0)
-	if fmtManifestPath != "" {
-		fetchers = append(fetchers, getManifests)
+	if manifestPath != "" {
+		fetchers = append(fetchers, getManifests(manifestPath))
 	}
 
-	if fmtOverridesPath != "" {
-		fetchers = append(fetchers, getOverrides)
+	if overridesPath != "" {
+		fetchers = append(fetchers, getOverrides(overridesPath))
 	}
 
-	if fmtEnvsPath != "" {
-		fetchers = append(fetchers, getEnvs)
+	if envsPath != "" {
+		fetchers = append(fetchers, getEnvs(envsPath))
 	}
 	return fetchers
 }
@@ -89,7 +94,9 @@ func getContents(fs afero.Fs, path string, readFile readFileContent) (fileConten
 			errors = append(errors, fmt.Sprintf("unable to read content %s, reason: %v", file.Name, err.Error()))
 			continue
 		}
-		result = append(result, fileContent{fileName: file.Name, content: content})
+		kind, _ := file.Kind()
+		version, _ := file.Version()
+		result = append(result, fileContent{fileName: file.Name, kind: kind, version: version, content: content})
 	}
 	if len(errors) != 0 {
 		return nil, errors
@@ -97,25 +104,55 @@ func getContents(fs afero.Fs, path string, readFile readFileContent) (fileConten
 	return result, nil
 }
 
-func getOverrides(fs afero.Fs) (fileContents, error) {
-	return getContents(fs, fmtOverridesPath, func(reader io.Reader) (fmt.Formatter, error) {
-		return stevedore.NewOverrides(reader)
-	})
+func getOverrides(path string) contentFetcher {
+	return func(fs afero.Fs) (fileContents, error) {
+		return getContents(fs, path, func(reader io.Reader) (fmt.Formatter, error) {
+			return stevedore.NewOverrides(reader)
+		})
+	}
 }
 
-func getEnvs(fs afero.Fs) (fileContents, error) {
-	return getContents(fs, fmtEnvsPath, func(reader io.Reader) (fmt.Formatter, error) {
-		return stevedore.NewEnv(reader)
-	})
+func getEnvs(path string) contentFetcher {
+	return func(fs afero.Fs) (fileContents, error) {
+		return getContents(fs, path, func(reader io.Reader) (fmt.Formatter, error) {
+			return stevedore.NewEnv(reader)
+		})
+	}
 }
 
-func getManifests(fs afero.Fs) (fileContents, error) {
-	return getContents(fs, fmtManifestPath, func(reader io.Reader) (fmt.Formatter, error) {
-		return stevedore.NewManifest(reader)
-	})
+func getManifests(path string) contentFetcher {
+	return func(fs afero.Fs) (fileContents, error) {
+		return getContents(fs, path, func(reader io.Reader) (fmt.Formatter, error) {
+			return stevedore.NewManifest(reader)
+		})
+	}
 }
 
-func format(fs afero.Fs, fileContents ...fileContent) error {
+// format saves the formatted contents. The contents decoded from an older version of their kind
+// are saved in the current version only when migrate is true, otherwise they are reported and left untouched
+func format(fs afero.Fs, writer io.Writer, migrate bool, contents ...fileContent) error {
+	var formatted fileContents
+	for _, content := range contents {
+		if !migrate && !stevedore.IsCurrentVersion(content.kind, content.version) {
+			_, _ = fmt.Fprintf(writer, "skipped %s in version %s, specify --migrate to format it in version %s\n", content.fileName, content.version, stevedore.CurrentVersion(content.kind))
+			continue
+		}
+		formatted = append(formatted, content)
+	}
+	return save(fs, migrated(writer), formatted...)
+}
+
+// migrated returns the callback for save, which reports the contents saved in the current version of their kind
+func migrated(writer io.Writer) func(fileContent) {
+	return func(content fileContent) {
+		if !stevedore.IsCurrentVersion(content.kind, content.version) {
+			_, _ = fmt.Fprintf(writer, "migrated %s from version %s to %s\n", content.fileName, content.version, stevedore.CurrentVersion(content.kind))
+		}
+	}
+}
+
+// save writes the formatted contents, calling saved with each of the contents once it is written
+func save(fs afero.Fs, saved func(fileContent), fileContents ...fileContent) error {
 	errors := formatErrors{}
 	for _, fileContent := range fileContents {
 		formatted := fmt.Sprintf("%y", fileContent.content)
@@ -125,6 +162,9 @@ func format(fs afero.Fs, fileContents ...fileContent) error {
 			errors = append(errors, fmt.Sprintf("unable to write override %s, reason: %v", fileContent.fileName, err.Error()))
 			continue
 		}
+		if saved != nil {
+			saved(fileContent)
+		}
 	}
 	if len(errors) != 0 {
 		return errors
@@ -137,5 +177,6 @@ func init() {
 	fmtCmd.PersistentFlags().StringVarP(&fmtManifestPath, "manifests-path", "f", "", "Stevedore manifest(s) path (can be yaml file or folder)")
 	fmtCmd.PersistentFlags().StringVarP(&fmtEnvsPath, "envs-path", "e", "", "Stevedore env(s) path (can be yaml file or folder)")
 	fmtCmd.PersistentFlags().StringVarP(&fmtOverridesPath, "overrides-path", "o", "", "Stevedore overrides path (can be yaml file or folder)")
+	fmtCmd.PersistentFlags().BoolVar(&fmtMigrate, "migrate", false, "Migrate the yaml(s) of an older version to the latest version while formatting (default: false)")
 	rootCmd.AddCommand(fmtCmd)
 }
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"testing"
 
 	"github.com/gojek/stevedore/pkg/stevedore"
@@ -45,7 +47,7 @@ spec:
 		})
 		assert.NoError(t, err)
 
-		err = format(fs, contents...)
+		err = format(fs, ioutil.Discard, false, contents...)
 		assert.NoError(t, err)
 
 		actual, err := afero.ReadFile(fs, manifestFilePath)
@@ -102,7 +104,7 @@ spec:
 		})
 		assert.NoError(t, err)
 
-		err = format(fs, contents...)
+		err = format(fs, ioutil.Discard, false, contents...)
 		assert.NoError(t, err)
 
 		actual, err := afero.ReadFile(fs, overridesPath)
@@ -149,7 +151,7 @@ spec:
 		})
 		assert.NoError(t, err)
 
-		err = format(fs, contents...)
+		err = format(fs, ioutil.Discard, false, contents...)
 		assert.NoError(t, err)
 
 		actual, err := afero.ReadFile(fs, envFilePath)
@@ -174,4 +176,27 @@ spec:
 
 		assert.Equal(t, expected, string(actual))
 	})
+	t.Run("should leave the yaml(s) of an older version untouched unless migrating", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		outdated := "kind: StevedoreOverride\nversion: \"1\"\nspec: []\n"
+		_ = afero.WriteFile(fs, "/mock/outdated.yaml", []byte(outdated), 0644)
+		overrides := stevedore.Overrides{Kind: stevedore.KindStevedoreOverride, Version: stevedore.OverrideCurrentVersion, Spec: stevedore.OverrideSpecifications{}}
+		contents := fileContents{{fileName: "/mock/outdated.yaml", kind: stevedore.KindStevedoreOverride, version: "1", content: overrides}}
+		out := &bytes.Buffer{}
+
+		err := format(fs, out, false, contents...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "skipped /mock/outdated.yaml in version 1, specify --migrate to format it in version 2\n", out.String())
+		actual, _ := afero.ReadFile(fs, "/mock/outdated.yaml")
+		assert.Equal(t, outdated, string(actual))
+
+		out.Reset()
+		err = format(fs, out, true, contents...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "migrated /mock/outdated.yaml from version 1 to 2\n", out.String())
+		actual, _ = afero.ReadFile(fs, "/mock/outdated.yaml")
+		assert.Equal(t, "kind: StevedoreOverride\nversion: \"2\"\nspec: []\n", string(actual))
+	})
 }
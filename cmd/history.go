@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/cmd/manifest"
+)
+
+func init() {
+	action := manifest.NewHistoryCmd(fs, &cfgFile, true)
+	command, err := action.CobraCommand()
+	cli.DieIf(err, closePlugins)
+	rootCmd.AddCommand(command)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gojek/stevedore/client/provider"
+	"github.com/gojek/stevedore/client/yaml"
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/file"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/lint"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	yamlv2 "gopkg.in/yaml.v2"
+)
+
+const lintOutputJSON = "json"
+
+var (
+	lintManifestPath  string
+	lintOverridesPath string
+	lintEnvsPath      string
+	lintDisabledRules []string
+	lintOutput        string
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint stevedore yaml(s)",
+	Long: `Lint stevedore manifests, overrides, envs and ignores.
+Rules which need the contexts use the contexts from the stevedore config.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if lintManifestPath == "" {
+			return fmt.Errorf("provide a valid path to stevedore manifests using --manifests-path")
+		}
+		if lintOutput != "" && lintOutput != lintOutputJSON {
+			return fmt.Errorf("invalid output format %s. Provide %s using --output", lintOutput, lintOutputJSON)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input, err := lintInput(fs, cfgFile)
+		if err != nil {
+			return err
+		}
+
+		issues, err := lint.Run(input, lintDisabledRules...)
+		if err != nil {
+			return err
+		}
+
+		if err := printIssues(cli.OutputStream(), issues, lintOutput); err != nil {
+			return err
+		}
+		if issues.HasErrors() {
+			return fmt.Errorf("lint failed with error(s)")
+		}
+		return nil
+	},
+}
+
+func lintInput(fs afero.Fs, configFile string) (lint.Input, error) {
+	manifests, err := lintManifests(fs, lintManifestPath)
+	if err != nil {
+		return lint.Input{}, err
+	}
+
+	overrides, err := provider.NewOverrideProvider(fs, lintOverridesPath).Overrides()
+	if err != nil {
+		return lint.Input{}, err
+	}
+
+	envsFiles, err := provider.NewEnvProvider(fs, lintEnvsPath).Envs()
+	if err != nil {
+		return lint.Input{}, err
+	}
+	envs := make([]lint.EnvFile, 0, len(envsFiles))
+	for _, envsFile := range envsFiles {
+		envs = append(envs, lint.EnvFile{Name: envsFile.Name, Specs: envsFile.EnvSpecifications})
+	}
+
+	ignores, err := lintIgnores(fs, lintManifestPath)
+	if err != nil {
+		return lint.Input{}, err
+	}
+
+	var contexts stevedore.Contexts
+	if exists, _ := afero.Exists(fs, configFile); exists {
+		stevedoreConfig, err := stevedore.NewConfigurationFromFile(fs, configFile, localStore)
+		if err != nil {
+			return lint.Input{}, err
+		}
+		if contexts, err = stevedoreConfig.ConfiguredContexts(); err != nil {
+			return lint.Input{}, err
+		}
+	}
+
+	return lint.Input{
+		Manifests: manifests,
+		Overrides: overrides,
+		Envs:      envs,
+		Ignores:   ignores,
+		Contexts:  contexts,
+		Charts:    helm.NewLocalChartLocator(),
+	}, nil
+}
+
+// lintManifests reads the manifests without validating them, so that the invalid ones can be reported by the rules
+func lintManifests(fs afero.Fs, path string) (stevedore.ManifestFiles, error) {
+	yamlFiles, err := yaml.NewYamlFiles(fs, path)
+	if _, ok := err.(yaml.EmptyFolderError); ok {
+		return stevedore.ManifestFiles{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	fileErrors := file.Errors{}
+	manifests := stevedore.ManifestFiles{}
+	for _, yamlFile := range yamlFiles {
+		ok, err := yamlFile.Check(stevedore.KindStevedoreManifest, stevedore.SupportedVersions(stevedore.KindStevedoreManifest)...)
+		if err != nil {
+			fileErrors = append(fileErrors, file.Error{Filename: yamlFile.Name, Reason: err})
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		reader, err := stevedore.ConvertToCurrentVersion(yamlFile.Reader())
+		if err != nil {
+			fileErrors = append(fileErrors, file.Error{Filename: yamlFile.Name, Reason: err})
+			continue
+		}
+
+		manifest := stevedore.Manifest{}
+		if err := yamlv2.NewDecoder(reader).Decode(&manifest); err != nil {
+			fileErrors = append(fileErrors, file.Error{Filename: yamlFile.Name, Reason: err})
+			continue
+		}
+		manifests = append(manifests, stevedore.ManifestFile{File: yamlFile.Name, Manifest: manifest})
+	}
+
+	if len(fileErrors) != 0 {
+		return nil, fileErrors
+	}
+	return manifests, nil
+}
+
+func lintIgnores(fs afero.Fs, manifestPath string) ([]lint.IgnoreFile, error) {
+	ignoreProvider, err := provider.NewIgnoreProvider(fs, manifestPath, localStore)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreFiles, err := ignoreProvider.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]lint.IgnoreFile, 0, len(ignoreFiles))
+	for _, ignoreFile := range ignoreFiles {
+		reader, err := fs.Open(ignoreFile)
+		if err != nil {
+			return nil, err
+		}
+		ignores, err := stevedore.NewIgnores(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, file.Errors{file.Error{Filename: ignoreFile, Reason: err}}
+		}
+		result = append(result, lint.IgnoreFile{Name: ignoreFile, Ignores: ignores})
+	}
+	return result, nil
+}
+
+func printIssues(writer io.Writer, issues lint.Issues, output string) error {
+	if output == lintOutputJSON {
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(writer, string(data))
+		return err
+	}
+
+	for _, issue := range issues {
+		_, _ = fmt.Fprintln(writer, issue.String())
+	}
+	_, err := fmt.Fprintf(writer, "found %d issue(s)\n", len(issues))
+	return err
+}
+
+func init() {
+	lintCmd.PersistentFlags().StringVarP(&lintManifestPath, "manifests-path", "f", "", "Stevedore manifest(s) path (can be yaml file or folder)")
+	lintCmd.PersistentFlags().StringVarP(&lintEnvsPath, "envs-path", "e", "", "Stevedore env(s) path (can be yaml file or folder)")
+	lintCmd.PersistentFlags().StringVarP(&lintOverridesPath, "overrides-path", "o", "", "Stevedore overrides path (can be yaml file or folder)")
+	lintCmd.PersistentFlags().StringSliceVar(&lintDisabledRules, "disable", nil, fmt.Sprintf("Rules to be disabled (%s)", lint.All().IDs()))
+	lintCmd.PersistentFlags().StringVar(&lintOutput, "output", "", fmt.Sprintf("Output the issues in the given format (%s)", lintOutputJSON))
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/lint"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintManifests(t *testing.T) {
+	t.Run("should read manifests without validating them", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		manifest := `kind: StevedoreManifest
+version: "2"
+deployTo:
+- contextName: cluster-1
+spec:
+- release:
+    name: x-service
+    namespace: default
+    chart: chart/x-service
+    chartSpec:
+      name: x-service
+      dependencies:
+      - name: redis
+`
+		_ = afero.WriteFile(fs, "/tmp/manifests/x-service.yaml", []byte(manifest), 0644)
+		_ = afero.WriteFile(fs, "/tmp/manifests/overrides.yaml", []byte("kind: StevedoreOverrides\nversion: \"2\"\nspec: []"), 0644)
+
+		manifests, err := lintManifests(fs, "/tmp/manifests")
+
+		assert.NoError(t, err)
+		if assert.Len(t, manifests, 1) {
+			assert.Equal(t, "/tmp/manifests/x-service.yaml", manifests[0].File)
+			assert.Equal(t, "chart/x-service", manifests[0].Spec[0].Release.Chart)
+			assert.Equal(t, "x-service", manifests[0].Spec[0].Release.ChartSpec.Name)
+		}
+	})
+
+	t.Run("should convert manifests of an older version before reading them", func(t *testing.T) {
+		// version 1 of the manifest declared the release specifications as releases, instead of spec
+		stevedore.RegisterConverter(stevedore.Converter{
+			Kind: stevedore.KindStevedoreManifest,
+			From: "1",
+			To:   "2",
+			Convert: func(document stevedore.Document) (stevedore.Document, error) {
+				document["spec"] = document["releases"]
+				delete(document, "releases")
+				return document, nil
+			},
+		})
+		fs := afero.NewMemMapFs()
+		manifest := `kind: StevedoreManifest
+version: "1"
+deployTo:
+- contextName: cluster-1
+releases:
+- release:
+    name: x-service
+    namespace: default
+    chart: chart/x-service
+`
+		_ = afero.WriteFile(fs, "/tmp/manifests/x-service.yaml", []byte(manifest), 0644)
+
+		manifests, err := lintManifests(fs, "/tmp/manifests")
+
+		assert.NoError(t, err)
+		if assert.Len(t, manifests, 1) && assert.Len(t, manifests[0].Spec, 1) {
+			assert.Equal(t, "2", manifests[0].Version)
+			assert.Equal(t, "x-service", manifests[0].Spec[0].Release.Name)
+		}
+	})
+}
+
+func TestPrintIssues(t *testing.T) {
+	issues := lint.Issues{
+		{Rule: lint.MissingDependency, Severity: lint.SeverityError, File: "x-service.yaml", Message: "release x-service depends on y-service, which is not declared in any manifest"},
+	}
+
+	t.Run("should print the issues as text", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := printIssues(buffer, issues, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "error [missing-dependency] x-service.yaml: release x-service depends on y-service, which is not declared in any manifest\nfound 1 issue(s)\n", buffer.String())
+	})
+
+	t.Run("should print the issues as json", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := printIssues(buffer, issues, lintOutputJSON)
+
+		assert.NoError(t, err)
+		expected := `[
+  {
+    "rule": "missing-dependency",
+    "severity": "error",
+    "file": "x-service.yaml",
+    "message": "release x-service depends on y-service, which is not declared in any manifest"
+  }
+]
+`
+		assert.Equal(t, expected, buffer.String())
+	})
+}
@@ -1,5 +1,10 @@
 package manifest
 
+import (
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+)
+
 // Action type represents manifest related actions
 type Action interface {
 	Do() (Info, error)
@@ -7,14 +12,36 @@ type Action interface {
 
 // NewAction to create action based on command name
 func NewAction(cmd Command, info Info) Action {
+	client := newHelmClient(info.Context, cmd.kubeconfig, cmd.kubeToken, cmd.kubeAPIServer)
+	summary := summaryFile{fs: cmd.fs, action: cmd.name, format: cmd.summary, path: cmd.summaryFile}
 	switch cmd.name {
 	case applyCommand:
-		return NewHelmAction(info, cmd.kubeconfig, false, false, false, cmd.helmRepoName,
-			cmd.helmTimeout, cmd.helmAtomic)
+		return NewHelmAction(info, client, false, true, false, cmd.helmRepoName,
+			cmd.helmTimeout, cmd.helmAtomic, cmd.ignoreDrift, cmd.prune, "", summary)
 	case planCommand:
-		return NewHelmAction(info, cmd.kubeconfig, true, true, true, cmd.helmRepoName,
-			cmd.helmTimeout, false)
+		return NewHelmAction(info, client, true, true, true, cmd.helmRepoName,
+			cmd.helmTimeout, false, false, false, cmd.output, summary)
+	case destroyCommand:
+		return NewDestroyAction(info, client, cmd.dryRun, cmd.helmTimeout)
+	case rollbackCommand:
+		return NewRollbackAction(info, client, cmd.dryRun, cmd.helmTimeout)
 	default:
-		return RenderAction{info: info}
+		return RenderAction{info: info, explain: cmd.explain, charts: helm.NewLocalChartLocator()}
+	}
+}
+
+// newHelmClient returns the helm client which interacts with the kubernetes cluster of the given context
+func newHelmClient(ctx stevedore.Context, kubeconfig, kubeToken, kubeAPIServer string) helm.Client {
+	return helm.NewClient(kubeOptions(ctx, kubeconfig, kubeToken, kubeAPIServer))
+}
+
+// kubeOptions returns the options to connect to the kubernetes cluster of the given context,
+// which are shared by the helm client and the kubernetes config provider
+func kubeOptions(ctx stevedore.Context, kubeconfig, kubeToken, kubeAPIServer string) helm.KubeOptions {
+	return helm.KubeOptions{
+		KubeConfig:  kubeconfig,
+		KubeContext: ctx.KubernetesContext,
+		BearerToken: kubeToken,
+		APIServer:   kubeAPIServer,
 	}
 }
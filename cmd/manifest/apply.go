@@ -29,7 +29,8 @@ func NewManifests(
 	}
 
 	filteredEnvs := envs.Filter(ctx)
-	substitutes, err := filteredEnvs.SortAndMerge(environment.Fetch())
+	environmentEnvs := environment.Fetch()
+	substitutes, err := filteredEnvs.SortAndMerge(environmentEnvs, ctx.ConditionWeights())
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +63,8 @@ func NewManifests(
 	if err != nil {
 		return nil, err
 	}
+	info.Sensitive = envs.Sensitive()
+	info.EnvSources = filteredEnvs.Sources(environmentEnvs, ctx.ConditionWeights())
 	reporter.ReportSkipped(info.Ignored)
 	reporter.ReportManifest(info.ManifestFiles)
 
@@ -214,6 +214,7 @@ func TestNewManifest(t *testing.T) {
 				{Name: "z-stevedore", Reason: "Not applicable for the context 'components-staging'"},
 				{Name: "y-stevedore", Reason: ""},
 			},
+			Applicable: stevedore.Manifests{manifestFiles[0].Manifest, manifestFiles[1].Manifest},
 			ManifestFiles: stevedore.ManifestFiles{
 				stevedore.ManifestFile{
 					File: manifestFileName,
@@ -254,7 +255,7 @@ func TestNewManifest(t *testing.T) {
 
 		assert.Nil(t, err)
 		if assert.NotNil(t, manifests) {
-			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{})
+			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{}, stevedore.Provenance{}, stevedore.Sources{})
 			info := *manifests
 
 			isEqual := cmp.Equal(expected, info, ignoreTypes)
@@ -411,7 +412,8 @@ func TestNewManifest(t *testing.T) {
 				KubernetesContext: "components",
 				EnvironmentType:   "staging",
 			},
-			Ignored: stevedore.IgnoredReleases{{Name: "y-stevedore"}},
+			Ignored:    stevedore.IgnoredReleases{{Name: "y-stevedore"}},
+			Applicable: stevedore.Manifests{manifestFiles[0].Manifest, manifestFiles[1].Manifest},
 			ManifestFiles: stevedore.ManifestFiles{
 				stevedore.ManifestFile{
 					File: manifestFileName,
@@ -452,7 +454,7 @@ func TestNewManifest(t *testing.T) {
 
 		assert.Nil(t, err)
 		if assert.NotNil(t, manifests) {
-			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{})
+			ignoreTypes := cmpopts.IgnoreTypes(stevedore.Substitute{}, stevedore.Overrides{}, stevedore.Provenance{}, stevedore.Sources{})
 			info := *manifests
 
 			isEqual := cmp.Equal(expected, info, ignoreTypes)
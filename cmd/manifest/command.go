@@ -4,14 +4,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gojek/stevedore/client/provider"
 	"github.com/gojek/stevedore/cmd/kubeconfig"
 	"github.com/gojek/stevedore/cmd/repo"
 	"github.com/gojek/stevedore/pkg/config"
+	"github.com/gojek/stevedore/pkg/stevedore"
 	"github.com/spf13/pflag"
 
 	"github.com/gojek/stevedore/cmd/cli"
+	cmdConfig "github.com/gojek/stevedore/cmd/config"
 	"github.com/gojek/stevedore/cmd/plugin"
 	"github.com/gojek/stevedore/cmd/store"
 	"github.com/manifoldco/promptui"
@@ -28,6 +31,8 @@ type Command struct {
 	fs                 afero.Fs
 	cfgFile            *string
 	kubeconfig         string
+	kubeToken          string
+	kubeAPIServer      string
 	kubeconfigRequired bool
 	useHelm            bool
 	askConfirmation    bool
@@ -37,12 +42,29 @@ type Command struct {
 	helmTimeout        int64
 	hasHelmAtomic      bool
 	helmAtomic         bool
+	hasIgnoreDrift     bool
+	ignoreDrift        bool
+	dryRunUsage        string
+	hasPrune           bool
+	prune              bool
+	hasOutput          bool
+	output             string
+	hasSummary         bool
+	summary            string
+	summaryFile        string
+	hasExplain         bool
+	explain            bool
+	fetchWorkers       int
+	configCacheDir     string
+	configCacheTTL     time.Duration
 }
 
 const (
-	applyCommand  = "apply"
-	planCommand   = "plan"
-	renderCommand = "render"
+	applyCommand    = "apply"
+	planCommand     = "plan"
+	renderCommand   = "render"
+	destroyCommand  = "destroy"
+	rollbackCommand = "rollback"
 )
 
 // NewApplyCmd creates a apply command
@@ -57,6 +79,9 @@ func NewApplyCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *Command
 		confirm:            false,
 		kubeconfigRequired: kubeconfigRequired,
 		hasHelmAtomic:      true,
+		hasIgnoreDrift:     true,
+		hasPrune:           true,
+		hasSummary:         true,
 	}
 }
 
@@ -68,6 +93,34 @@ func NewPlanCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *Command
 		cfgFile:            cfgFile,
 		useHelm:            true,
 		kubeconfigRequired: kubeconfigRequired,
+		hasOutput:          true,
+		hasSummary:         true,
+	}
+}
+
+// NewDestroyCmd creates a destroy command
+func NewDestroyCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *Command {
+	return &Command{
+		name:               destroyCommand,
+		fs:                 fs,
+		cfgFile:            cfgFile,
+		useHelm:            true,
+		askConfirmation:    true,
+		kubeconfigRequired: kubeconfigRequired,
+		dryRunUsage:        "List the resources which would be removed, without running destroy",
+	}
+}
+
+// NewRollbackCmd creates a rollback command
+func NewRollbackCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *Command {
+	return &Command{
+		name:               rollbackCommand,
+		fs:                 fs,
+		cfgFile:            cfgFile,
+		useHelm:            true,
+		askConfirmation:    true,
+		kubeconfigRequired: kubeconfigRequired,
+		dryRunUsage:        "Show the changes which would be made to the releases, without running rollback",
 	}
 }
 
@@ -78,10 +131,11 @@ func NewRenderCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *Comman
 		fs:                 fs,
 		cfgFile:            cfgFile,
 		kubeconfigRequired: kubeconfigRequired,
+		hasExplain:         true,
 	}
 }
 
-func promptConfirmation() (string, error) {
+func promptConfirmation(action string) (string, error) {
 	templates := &promptui.PromptTemplates{
 		Confirm: "{{ . | yellow }}",
 		Invalid: "{{ . | red }}",
@@ -89,7 +143,7 @@ func promptConfirmation() (string, error) {
 	}
 
 	prompt := promptui.Prompt{
-		Label:     "Confirm to apply: [y/N] ",
+		Label:     fmt.Sprintf("Confirm to %s: [y/N] ", action),
 		IsConfirm: true,
 		Templates: templates,
 	}
@@ -97,6 +151,45 @@ func promptConfirmation() (string, error) {
 	return prompt.Run()
 }
 
+// resolveContext returns the current stevedore context along with the kubeconfig
+// resolved for it, when kubeconfig is required
+func resolveContext(contextProvider provider.ContextProvider, fs afero.Fs, kubeconfigPath string, kubeconfigRequired bool) (stevedore.Context, string, error) {
+	ctx, err := contextProvider.Context()
+	if err != nil {
+		return stevedore.Context{}, "", err
+	}
+	cli.Infof(ctx.String())
+
+	if !kubeconfigRequired {
+		return ctx, kubeconfigPath, nil
+	}
+	resolvedKubeconfig, err := kubeconfig.ResolveAndValidate(kubeconfig.OSHomeDirResolver, kubeconfigPath, fs, ctx)
+	if err != nil {
+		return stevedore.Context{}, "", err
+	}
+	return ctx, resolvedKubeconfig, nil
+}
+
+// configCache returns the cache for the fetches of the config providers, which is stored on disk when a ttl is given
+func (actionCmd *Command) configCache() *config.Cache {
+	if actionCmd.configCacheTTL <= 0 {
+		return config.NewCache(actionCmd.fetchWorkers, nil)
+	}
+	return config.NewCache(actionCmd.fetchWorkers, &config.DiskCache{Fs: actionCmd.fs, Dir: actionCmd.configCacheDir, TTL: actionCmd.configCacheTTL})
+}
+
+// addKubeFlags adds the flags to connect to the kubernetes cluster
+func addKubeFlags(cmd *cobra.Command, kubeconfigPath, kubeToken, kubeAPIServer *string) error {
+	defaultFile, err := kubeconfig.DefaultFile(kubeconfig.OSHomeDirResolver)
+	if err != nil {
+		return err
+	}
+	cmd.PersistentFlags().StringVar(kubeconfigPath, "kubeconfig", "", fmt.Sprintf("path to kubeconfig file (default: %s)", defaultFile))
+	cmd.PersistentFlags().StringVar(kubeToken, "kube-token", "", "bearer token used for authentication to the kubernetes cluster")
+	cmd.PersistentFlags().StringVar(kubeAPIServer, "kube-apiserver", "", "the address and the port for the kubernetes API server")
+	return nil
+}
+
 // CobraCommand builds a cobra command for the action
 func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 	shortDesc := fmt.Sprintf("%s stevedore yaml(s)", strings.Title(actionCmd.name))
@@ -119,6 +212,18 @@ func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 			if _, err := os.Stat(actionCmd.overridesPath); actionCmd.overridesPath != "" && os.IsNotExist(err) {
 				return fmt.Errorf("invalid file path. Provide a valid path to stevedore manifests using --overrides-path")
 			}
+			if !isValidOutputFormat(actionCmd.output) {
+				return fmt.Errorf("invalid output format %s. Provide either %s or %s using --output", actionCmd.output, outputJSON, outputYAML)
+			}
+			if actionCmd.summary != "" && actionCmd.summary != summaryMarkdown {
+				return fmt.Errorf("invalid summary format %s. Provide %s using --summary", actionCmd.summary, summaryMarkdown)
+			}
+			if actionCmd.summary != "" && actionCmd.summaryFile == "" {
+				return fmt.Errorf("summary file is not provided. Provide the path to write the summary using --summary-file")
+			}
+			if actionCmd.output != "" {
+				cli.SetOutputStream(os.Stderr)
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -128,22 +233,14 @@ func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 			envProvider := provider.NewEnvProvider(actionCmd.fs, actionCmd.envsPath)
 			reporter := DefaultReporter{}
 
-			ctx, err := contextProvider.Context()
+			stevedoreContext, resolvedKubeconfig, err := resolveContext(contextProvider, actionCmd.fs, actionCmd.kubeconfig, actionCmd.kubeconfigRequired)
 			if err != nil {
 				return err
 			}
-			cli.Infof(ctx.String())
-
-			if actionCmd.kubeconfigRequired {
-				resolvedKubeconfig, err := kubeconfig.ResolveAndValidate(kubeconfig.OSHomeDirResolver, actionCmd.kubeconfig, actionCmd.fs, ctx)
-				if err != nil {
-					return err
-				}
-				actionCmd.kubeconfig = resolvedKubeconfig
-			}
+			actionCmd.kubeconfig = resolvedKubeconfig
 
-			if actionCmd.askConfirmation && !actionCmd.confirm {
-				_, err := promptConfirmation()
+			if actionCmd.askConfirmation && !actionCmd.confirm && !actionCmd.dryRun {
+				_, err := promptConfirmation(actionCmd.name)
 
 				if err != nil {
 					fmt.Printf("Command Cancelled %v\n", err)
@@ -172,6 +269,8 @@ func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 				})
 				updatedConfigProviders = append(updatedConfigProviders, configProvider)
 			}
+			updatedConfigProviders = updatedConfigProviders.WithCache(actionCmd.configCache())
+			updatedConfigProviders = provider.WithKubeOptions(updatedConfigProviders, kubeOptions(stevedoreContext, actionCmd.kubeconfig, actionCmd.kubeToken, actionCmd.kubeAPIServer))
 			manifestProvider.Context = make(map[string]string)
 			flags.VisitAll(func(flag *pflag.Flag) {
 				if strings.HasPrefix(flag.Name, manifestProvider.Name) {
@@ -214,7 +313,32 @@ func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 	cmd.PersistentFlags().StringVarP(&actionCmd.overridesPath, "overrides-path", "o", "", "Stevedore overrides path (can be yaml file or folder)")
 
 	if actionCmd.askConfirmation {
-		cmd.PersistentFlags().BoolVar(&actionCmd.confirm, "yes", actionCmd.confirm, "Confirm to apply")
+		cmd.PersistentFlags().BoolVar(&actionCmd.confirm, "yes", actionCmd.confirm, fmt.Sprintf("Confirm to %s", actionCmd.name))
+	}
+
+	defaultCacheDir, err := cmdConfig.CacheDirPath()
+	if err != nil {
+		return nil, err
+	}
+	cmd.PersistentFlags().IntVar(&actionCmd.fetchWorkers, "config-fetch-workers", config.DefaultWorkers, "Number of configs fetched from the config providers concurrently")
+	cmd.PersistentFlags().DurationVar(&actionCmd.configCacheTTL, "config-cache-ttl", 0, "Duration for which the configs fetched from the remote config providers, except the sensitive ones, are cached on disk (default: 0, not cached)")
+	cmd.PersistentFlags().StringVar(&actionCmd.configCacheDir, "config-cache-dir", defaultCacheDir, "Directory in which the configs are cached")
+
+	if actionCmd.hasOutput {
+		cmd.PersistentFlags().StringVar(&actionCmd.output, "output", "", fmt.Sprintf("Output the %s as a document in the given format (%s or %s) to stdout", actionCmd.name, outputJSON, outputYAML))
+	}
+
+	if actionCmd.hasExplain {
+		cmd.PersistentFlags().BoolVar(&actionCmd.explain, "explain", false, "Show the source of each of the rendered values (default: false)")
+	}
+
+	if actionCmd.hasSummary {
+		cmd.PersistentFlags().StringVar(&actionCmd.summary, "summary", "", fmt.Sprintf("Write the summary of the %s in the given format (%s) to the file given by --summary-file", actionCmd.name, summaryMarkdown))
+		cmd.PersistentFlags().StringVar(&actionCmd.summaryFile, "summary-file", "", "Path of the file to write the summary")
+	}
+
+	if actionCmd.dryRunUsage != "" {
+		cmd.PersistentFlags().BoolVar(&actionCmd.dryRun, "dry-run", false, actionCmd.dryRunUsage)
 	}
 
 	if actionCmd.useHelm {
@@ -223,14 +347,18 @@ func (actionCmd *Command) CobraCommand() (*cobra.Command, error) {
 		if actionCmd.hasHelmAtomic {
 			cmd.PersistentFlags().BoolVar(&actionCmd.helmAtomic, "helm-atomic", false, "Wait for resources to become ready and delete installation on failure (default: false)")
 		}
+		if actionCmd.hasPrune {
+			cmd.PersistentFlags().BoolVar(&actionCmd.prune, "prune", false, "Uninstall the releases managed by stevedore, which are no longer declared in manifests (default: false)")
+		}
+		if actionCmd.hasIgnoreDrift {
+			cmd.PersistentFlags().BoolVar(&actionCmd.ignoreDrift, "ignore-drift", false, "Apply even if a release has a new revision since it was planned (default: false)")
+		}
 	}
 
 	if actionCmd.kubeconfigRequired {
-		defaultFile, err := kubeconfig.DefaultFile(kubeconfig.OSHomeDirResolver)
-		if err != nil {
+		if err := addKubeFlags(&cmd, &actionCmd.kubeconfig, &actionCmd.kubeToken, &actionCmd.kubeAPIServer); err != nil {
 			return nil, err
 		}
-		cmd.PersistentFlags().StringVar(&actionCmd.kubeconfig, "kubeconfig", "", fmt.Sprintf("path to kubeconfig file (default: %s)", defaultFile))
 	}
 
 	err = plugins.PopulateFlags(&cmd)
@@ -0,0 +1,59 @@
+package manifest
+
+import (
+	"context"
+
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+)
+
+// DestroyAction to uninstall releases in manifest
+type DestroyAction struct {
+	info        Info
+	client      helm.Client
+	dryRun      bool
+	helmTimeout int64
+}
+
+// NewDestroyAction returns DestroyAction with given arguments
+func NewDestroyAction(info Info, client helm.Client, dryRun bool, helmTimeout int64) DestroyAction {
+	return DestroyAction{info, client, dryRun, helmTimeout}
+}
+
+// Do will uninstall releases in manifests, or list the resources to be removed on dry run
+func (action DestroyAction) Do() (Info, error) {
+	opts := stevedore.Opts{DryRun: action.dryRun, Filter: action.dryRun}
+	responses, err := stevedore.CreateDestroyResponse(context.TODO(), action.client, action.info.ManifestFiles, opts, action.helmTimeout)
+	if err != nil {
+		return Info{}, err
+	}
+	if len(responses) == 0 {
+		cli.Warn("No releases to destroy")
+		return Info{}, nil
+	}
+
+	handler := newReleaseResponseHandler(action.dryRun, "Destroyed Successfully", "Not installed, nothing to destroy")
+	return display(action.info, responses, handler)
+}
+
+// display renders the responses along with their summary and
+// returns the info of the releases in responses along with errors if any
+func display(info Info, responses stevedore.Responses, handler responseHandler) (Info, error) {
+	group := responses.GroupByFile()
+	if err := handler.render(group); err != nil {
+		return Info{}, err
+	}
+
+	summarizer := TableSummarizer{writer: cli.OutputStream()}
+	summarizer.Display(group)
+
+	filteredInfos := info.FilterBy(responses)
+	errors := getAllErrors(responses)
+
+	if len(errors) == 0 {
+		return filteredInfos, nil
+	}
+
+	return filteredInfos, errors
+}
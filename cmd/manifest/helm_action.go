@@ -4,56 +4,96 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/helm"
 	"github.com/gojek/stevedore/pkg/stevedore"
 )
 
 // HelmAction to apply manifest
 type HelmAction struct {
 	info         Info
-	kubeconfig   string
+	client       helm.Client
 	dryRun       bool
 	parallel     bool
 	filter       bool
 	helmRepoName string
 	helmTimeout  int64
 	helmAtomic   bool
+	ignoreDrift  bool
+	prune        bool
+	output       string
+	summary      summaryFile
 }
 
 type actionErrors []error
 
+// exit codes denoting the kind of failure
+const (
+	exitCodeFailure                = 1
+	exitCodeInitFailure            = 2
+	exitCodeChartLocationFailure   = 3
+	exitCodeDependencyCheckFailure = 4
+	exitCodeReleaseFailure         = 5
+	exitCodeDriftFailure           = 6
+)
+
 // NewHelmAction returns HelmAction with given arguments
-func NewHelmAction(info Info, kubeconfig string, dryRun bool, parallel bool, filter bool, helmRepoName string, helmTimeout int64, helmAtomic bool) HelmAction {
-	return HelmAction{info, kubeconfig, dryRun, parallel, filter, helmRepoName, helmTimeout, helmAtomic}
+func NewHelmAction(info Info, client helm.Client, dryRun bool, parallel bool, filter bool, helmRepoName string, helmTimeout int64, helmAtomic bool, ignoreDrift bool, prune bool, output string, summary summaryFile) HelmAction {
+	return HelmAction{info, client, dryRun, parallel, filter, helmRepoName, helmTimeout, helmAtomic, ignoreDrift, prune, output, summary}
 }
 
 // Do will plan/apply manifests
 func (action HelmAction) Do() (Info, error) {
 	manifestFiles := action.info.ManifestFiles
-	opts := stevedore.Opts{DryRun: action.dryRun, Parallel: action.parallel, Filter: action.filter}
+	opts := stevedore.Opts{DryRun: action.dryRun, Parallel: action.parallel, Filter: action.filter, IgnoreDrift: action.ignoreDrift}
+
+	responses, err := stevedore.CreateResponse(context.TODO(), action.client, manifestFiles, opts, action.helmRepoName, action.helmTimeout, action.helmAtomic)
 
-	responses, err := stevedore.CreateResponse(context.TODO(), manifestFiles, opts, action.helmRepoName, action.helmTimeout, action.helmAtomic)
+	// error of a failed release is part of the responses, and is returned after displaying them
+	if err != nil && len(responses) == 0 {
+		return Info{}, err
+	}
 
+	client := action.client
+	orphans, err := stevedore.FindOrphans(context.TODO(), client, action.info.Applicable)
 	if err != nil {
+		return Info{}, actionErrors{err}
+	}
+
+	redactor := action.info.Redactor()
+	responses = redactor.RedactResponses(responses)
+	if err := action.summary.write(responses.GroupByFile(), redactor); err != nil {
 		return Info{}, err
 	}
+
+	if action.output != "" {
+		return action.writeDocument(responses)
+	}
+
+	summarizer := TableSummarizer{writer: cli.OutputStream()}
 	if len(responses) == 0 {
 		cli.Warn("No changes in the plan")
+		if errors := action.handleOrphans(client, summarizer, orphans, nil); len(errors) != 0 {
+			return Info{}, errors
+		}
 		return Info{}, nil
 	}
 
 	group := responses.GroupByFile()
 	responseHandler := newResponseHandler(action.dryRun)
-	if err := responseHandler.render(group); err != nil {
-		return Info{}, err
-	}
+	// errors in responses are returned after displaying the summary
+	renderErr := responseHandler.render(group)
 
-	summarizer := TableSummarizer{writer: cli.OutputStream()}
 	summarizer.Display(group)
 
 	filteredInfos := action.info.FilterBy(responses)
 	errors := getAllErrors(responses)
+	if len(errors) == 0 && renderErr != nil {
+		errors = append(errors, renderErr)
+	}
+	errors = append(errors, action.handleOrphans(client, summarizer, orphans, errors)...)
 
 	if len(errors) == 0 {
 		return filteredInfos, nil
@@ -62,6 +102,55 @@ func (action HelmAction) Do() (Info, error) {
 	return filteredInfos, errors
 }
 
+// writeDocument writes the plan as PlanDocument in the output format to os.Stdout,
+// instead of rendering the responses and their summary
+func (action HelmAction) writeDocument(responses stevedore.Responses) (Info, error) {
+	if err := NewPlanDocument(action.info, responses).Write(os.Stdout, action.output); err != nil {
+		return Info{}, err
+	}
+	if len(responses) == 0 {
+		return Info{}, nil
+	}
+
+	filteredInfos := action.info.FilterBy(responses)
+	if errors := getAllErrors(responses); len(errors) != 0 {
+		return filteredInfos, errors
+	}
+	return filteredInfos, nil
+}
+
+// handleOrphans displays the orphaned releases, and uninstalls them
+// when applying with prune, unless applying the manifests has failed
+func (action HelmAction) handleOrphans(client helm.Client, summarizer TableSummarizer, orphans stevedore.OrphanedReleases, errors actionErrors) actionErrors {
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	summarizer.DisplayOrphans(orphans)
+	if action.dryRun {
+		return nil
+	}
+	if !action.prune {
+		cli.Warn("Orphaned releases are not uninstalled, specify --prune to uninstall them")
+		return nil
+	}
+	if len(errors) != 0 {
+		cli.Warn("Orphaned releases are not uninstalled, as applying the manifests has failed")
+		return nil
+	}
+
+	responses := orphans.Prune(context.TODO(), client, action.helmTimeout)
+	for _, response := range responses {
+		cli.Infof("ReleaseName: %s", response.ReleaseName)
+		if response.Err != nil {
+			cli.Errorf("Error: %v", response.Err)
+			continue
+		}
+		cli.Info("Pruned Successfully\n")
+	}
+	return getAllErrors(responses)
+}
+
 func getAllErrors(responses stevedore.Responses) actionErrors {
 	var errors actionErrors
 	for _, response := range responses {
@@ -79,3 +168,38 @@ func (errors actionErrors) Error() string {
 	}
 	return buff.String()
 }
+
+// ExitCode returns the status code denoting the kind of failure when all the errors
+// are of the same kind, otherwise it returns exitCodeFailure
+func (errors actionErrors) ExitCode() int {
+	code := exitCodeFailure
+	for i, err := range errors {
+		if i == 0 {
+			code = exitCode(err)
+		} else if exitCode(err) != code {
+			return exitCodeFailure
+		}
+	}
+	return code
+}
+
+func exitCode(err error) int {
+	failure, ok := err.(helm.Failure)
+	if !ok {
+		return exitCodeFailure
+	}
+	switch failure.Kind() {
+	case helm.InitFailure:
+		return exitCodeInitFailure
+	case helm.ChartLocationFailure:
+		return exitCodeChartLocationFailure
+	case helm.DependencyCheckFailure:
+		return exitCodeDependencyCheckFailure
+	case helm.ReleaseFailure:
+		return exitCodeReleaseFailure
+	case helm.DriftFailure:
+		return exitCodeDriftFailure
+	default:
+		return exitCodeFailure
+	}
+}
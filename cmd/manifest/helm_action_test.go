@@ -0,0 +1,68 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionErrors_ExitCode(t *testing.T) {
+	initErr := helm.InitError{ReleaseName: "redis", Namespace: "default", Err: fmt.Errorf("cluster unreachable")}
+	releaseErr := helm.ReleaseError{ReleaseName: "redis", Action: "installing", Err: fmt.Errorf("timed out")}
+
+	t.Run("should return the exit code of the kind of failure", func(t *testing.T) {
+		assert.Equal(t, exitCodeInitFailure, actionErrors{initErr, initErr}.ExitCode())
+		assert.Equal(t, exitCodeReleaseFailure, actionErrors{releaseErr}.ExitCode())
+		assert.Equal(t, exitCodeChartLocationFailure, actionErrors{helm.ChartLocationError{}}.ExitCode())
+		assert.Equal(t, exitCodeDependencyCheckFailure, actionErrors{helm.DependencyCheckError{}}.ExitCode())
+		assert.Equal(t, exitCodeDriftFailure, actionErrors{helm.ReleaseDriftError{}}.ExitCode())
+	})
+
+	t.Run("should return generic exit code when failures are of different kinds", func(t *testing.T) {
+		assert.Equal(t, exitCodeFailure, actionErrors{initErr, releaseErr}.ExitCode())
+	})
+
+	t.Run("should return generic exit code for other errors", func(t *testing.T) {
+		assert.Equal(t, exitCodeFailure, actionErrors{fmt.Errorf("some error")}.ExitCode())
+		assert.Equal(t, exitCodeFailure, actionErrors{releaseErr, fmt.Errorf("some error")}.ExitCode())
+	})
+}
+
+// unchangedClient is a helm.Client whose releases have no changes, and which fails to uninstall
+type unchangedClient struct {
+	helm.Client
+	managed []string
+}
+
+func (client unchangedClient) Upstall(_ context.Context, _, _, chartVersion string, _ int32, _, _ string, _ bool, _ int64, _ bool) (helm.UpstallResponse, error) {
+	return helm.UpstallResponse{ChartVersion: chartVersion}, nil
+}
+
+func (client unchangedClient) ManagedReleases(_ context.Context, _ string) ([]string, error) {
+	return client.managed, nil
+}
+
+func (client unchangedClient) Uninstall(_ context.Context, releaseName, _ string, _ bool, _ int64) (helm.UpstallResponse, error) {
+	return helm.UpstallResponse{}, fmt.Errorf("timed out")
+}
+
+func TestHelmActionDo(t *testing.T) {
+	t.Run("should return the errors of pruning when there are no changes", func(t *testing.T) {
+		manifest := stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+			{Release: stevedore.Release{Name: "app", Namespace: "default", Chart: "stable/app", ChartVersion: "1.0.0"}},
+		}}
+		info := Info{
+			ManifestFiles: stevedore.ManifestFiles{{File: "app.yaml", Manifest: manifest}},
+			Applicable:    stevedore.Manifests{manifest},
+		}
+		action := HelmAction{info: info, client: unchangedClient{managed: []string{"app", "old-app"}}, filter: true, prune: true}
+
+		_, err := action.Do()
+
+		assert.Equal(t, actionErrors{fmt.Errorf("error when uninstalling old-app due to timed out")}, err)
+	})
+}
@@ -50,8 +50,19 @@ func (p planResponseHandler) renderer(response stevedore.Response) error {
 
 type applyResponseHandler struct{}
 
+// render renders all the responses and returns the errors in them, if any
 func (a applyResponseHandler) render(group stevedore.GroupedResponses) error {
-	return iterator(group, a.renderer)
+	var errors actionErrors
+	_ = iterator(group, func(response stevedore.Response) error {
+		if err := a.renderer(response); err != nil {
+			errors = append(errors, err)
+		}
+		return nil
+	})
+	if len(errors) == 0 {
+		return nil
+	}
+	return errors
 }
 
 func (a applyResponseHandler) renderer(response stevedore.Response) error {
@@ -63,3 +74,34 @@ func (a applyResponseHandler) renderer(response stevedore.Response) error {
 	cli.Info("Applied Successfully\n")
 	return nil
 }
+
+// releaseResponseHandler renders all the responses, irrespective of errors in them
+type releaseResponseHandler struct {
+	successMessage string
+	noDiffMessage  string
+}
+
+func newReleaseResponseHandler(dryRun bool, successMessage, noDiffMessage string) responseHandler {
+	if dryRun {
+		return planResponseHandler{}
+	}
+	return releaseResponseHandler{successMessage: successMessage, noDiffMessage: noDiffMessage}
+}
+
+func (r releaseResponseHandler) render(group stevedore.GroupedResponses) error {
+	return iterator(group, r.renderer)
+}
+
+func (r releaseResponseHandler) renderer(response stevedore.Response) error {
+	cli.Infof("ReleaseName: %s", response.ReleaseName)
+	if response.Err != nil {
+		cli.Errorf("Error: %v", response.Err)
+		return nil
+	}
+	if !response.HasDiff {
+		cli.Info(r.noDiffMessage + "\n")
+		return nil
+	}
+	cli.Info(r.successMessage + "\n")
+	return nil
+}
@@ -94,4 +94,48 @@ func TestApply_render(t *testing.T) {
 
 		assert.Error(t, err, "error installing nginx")
 	})
+
+	t.Run("should render all responses and return all errors", func(t *testing.T) {
+		r := newResponseHandler(false)
+		group := stevedore.GroupedResponses{
+			"nginx.yaml": stevedore.Responses{
+				{
+					File:        "nginx.yaml",
+					ReleaseName: "nginx-a",
+					Err:         fmt.Errorf("error installing nginx-a"),
+				},
+				{
+					File:        "nginx.yaml",
+					ReleaseName: "nginx-b",
+					Err:         fmt.Errorf("error installing nginx-b"),
+				},
+			},
+		}
+		err := r.render(group)
+
+		assert.Equal(t, actionErrors{fmt.Errorf("error installing nginx-a"), fmt.Errorf("error installing nginx-b")}, err)
+	})
+}
+
+func TestRelease_render(t *testing.T) {
+	t.Run("should render all responses even when a response has error", func(t *testing.T) {
+		r := newReleaseResponseHandler(false, "Destroyed Successfully", "Nothing to destroy")
+		group := stevedore.GroupedResponses{
+			"nginx.yaml": stevedore.Responses{
+				{
+					File:        "nginx.yaml",
+					ReleaseName: "nginx-a",
+					Err:         fmt.Errorf("error uninstalling nginx"),
+				},
+				{
+					File:        "nginx.yaml",
+					ReleaseName: "nginx-b",
+					Err:         nil,
+				},
+			},
+		}
+		err := r.render(group)
+
+		assert.NoError(t, err)
+	})
 }
@@ -0,0 +1,81 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/gojek/stevedore/client/provider"
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/cmd/store"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// HistoryCommand can be used to create a cobra Command which shows the revisions of a release
+type HistoryCommand struct {
+	fs                 afero.Fs
+	cfgFile            *string
+	kubeconfig         string
+	kubeToken          string
+	kubeAPIServer      string
+	kubeconfigRequired bool
+	namespace          string
+}
+
+// NewHistoryCmd creates a history command
+func NewHistoryCmd(fs afero.Fs, cfgFile *string, kubeconfigRequired bool) *HistoryCommand {
+	return &HistoryCommand{fs: fs, cfgFile: cfgFile, kubeconfigRequired: kubeconfigRequired}
+}
+
+// CobraCommand builds a cobra command for history
+func (historyCmd *HistoryCommand) CobraCommand() (*cobra.Command, error) {
+	cmd := cobra.Command{
+		Use:           "history <release>",
+		Short:         "Show the revisions of a release",
+		Long:          "Show the revisions of a release, which can be used to rollback",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contextProvider := provider.NewContextProvider(historyCmd.fs, *historyCmd.cfgFile, store.Local{})
+			ctx, resolvedKubeconfig, err := resolveContext(contextProvider, historyCmd.fs, historyCmd.kubeconfig, historyCmd.kubeconfigRequired)
+			if err != nil {
+				return err
+			}
+			historyCmd.kubeconfig = resolvedKubeconfig
+
+			client := newHelmClient(ctx, historyCmd.kubeconfig, historyCmd.kubeToken, historyCmd.kubeAPIServer)
+			revisions, err := client.History(context.TODO(), args[0], historyCmd.namespace)
+			if err != nil {
+				return actionErrors{err}
+			}
+			displayRevisions(cli.OutputStream(), revisions)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&historyCmd.namespace, "namespace", "n", "default", "Namespace of the release")
+
+	if historyCmd.kubeconfigRequired {
+		if err := addKubeFlags(&cmd, &historyCmd.kubeconfig, &historyCmd.kubeToken, &historyCmd.kubeAPIServer); err != nil {
+			return nil, err
+		}
+	}
+	return &cmd, nil
+}
+
+func displayRevisions(writer io.Writer, revisions helm.Revisions) {
+	table := createTable(writer, []string{"REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "DESCRIPTION"}, false)
+	for _, revision := range revisions {
+		table.Append([]string{
+			fmt.Sprintf("%d", revision.Version),
+			revision.Updated.Format("Mon Jan _2 15:04:05 2006"),
+			revision.Status,
+			revision.Chart,
+			revision.AppVersion,
+			revision.Description,
+		})
+	}
+	table.Render()
+}
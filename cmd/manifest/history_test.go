@@ -0,0 +1,28 @@
+package manifest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayRevisions(t *testing.T) {
+	t.Run("should display revisions as table", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		revisions := helm.Revisions{
+			{Version: 1, Updated: time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC), Status: "superseded", Chart: "redis-1.0.0", AppVersion: "6.0", Description: "Install complete"},
+			{Version: 2, Updated: time.Date(2021, 8, 2, 10, 0, 0, 0, time.UTC), Status: "deployed", Chart: "redis-1.1.0", AppVersion: "6.0", Description: "Upgrade complete"},
+		}
+
+		displayRevisions(buffer, revisions)
+
+		output := buffer.String()
+		assert.Contains(t, output, "REVISION")
+		assert.Contains(t, output, "Sun Aug  1 10:00:00 2021")
+		assert.Contains(t, output, "redis-1.1.0")
+		assert.Contains(t, output, "Upgrade complete")
+	})
+}
@@ -10,13 +10,31 @@ import (
 // Info holds info manifestFiles and context
 type Info struct {
 	stevedore.ManifestFiles
-	Ignored stevedore.IgnoredReleases
+	Ignored    stevedore.IgnoredReleases
+	Applicable stevedore.Manifests
+	Sensitive  stevedore.SensitiveVariables
+	EnvSources stevedore.Sources
 	stevedore.Context
 }
 
+// Redactor returns the redactor for the values of sensitive variables used by the releases
+func (info Info) Redactor() stevedore.Redactor {
+	var substitutes []stevedore.Substitute
+	sensitive := append(stevedore.SensitiveVariables{}, info.Sensitive...)
+	for _, manifestFile := range info.ManifestFiles {
+		for _, releaseSpecification := range manifestFile.Manifest.Spec {
+			substitutes = append(substitutes, releaseSpecification.SubstitutedVariables(), releaseSpecification.SensitiveValues())
+			for name := range releaseSpecification.SensitiveValues() {
+				sensitive = append(sensitive, name)
+			}
+		}
+	}
+	return stevedore.NewRedactor(sensitive, substitutes...)
+}
+
 // FilterBy returns the info which matches the release names and populate the release data
 func (info *Info) FilterBy(responses stevedore.Responses) Info {
-	newInfo := Info{Ignored: info.Ignored, Context: info.Context}
+	newInfo := Info{Ignored: info.Ignored, Applicable: info.Applicable, Sensitive: info.Sensitive, EnvSources: info.EnvSources, Context: info.Context}
 
 	for _, manifestFile := range info.ManifestFiles {
 		releaseSpecifications := stevedore.ReleaseSpecifications{}
@@ -51,7 +69,7 @@ func info(manifests stevedore.ManifestFiles,
 	ignores stevedore.Ignores,
 	providers config.Providers) (*Info, error) {
 
-	enrichedManifestFiles, ignoredComponents, err := manifests.Enrich(overrides, stevedoreContext, envs, ignores, providers)
+	enrichedManifestFiles, ignoredComponents, err := manifests.TrackProvenance().Enrich(overrides, stevedoreContext, envs, ignores, providers)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +78,7 @@ func info(manifests stevedore.ManifestFiles,
 		Context:       stevedoreContext,
 		ManifestFiles: enrichedManifestFiles,
 		Ignored:       ignoredComponents,
+		Applicable:    manifests.Applicable(stevedoreContext),
 	}
 	return info, nil
 }
@@ -0,0 +1,120 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gojek/stevedore/log"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+)
+
+// summary formats
+const (
+	summaryMarkdown = "markdown"
+)
+
+// MarkdownSummarizer represents the summarizer that displays the summary
+// as GitHub flavored markdown, such as to comment on pull requests
+type MarkdownSummarizer struct {
+	writer   io.Writer
+	action   string
+	redactor stevedore.Redactor
+}
+
+// Display outputs the markdown summary to the MarkdownSummarizer.writer
+func (s MarkdownSummarizer) Display(group stevedore.GroupedResponses) {
+	buff := bytes.NewBufferString(fmt.Sprintf("## Stevedore %s\n\n", s.action))
+	buff.WriteString("| File | Additions | Modifications | Destructions |\n")
+	buff.WriteString("|------|-----------|---------------|--------------|\n")
+
+	for _, file := range group.SortedFileNames() {
+		additions, modifications, deletions := 0, 0, 0
+		for _, response := range group[file] {
+			summary := response.Summary()
+			additions += len(summary.Added)
+			modifications += len(summary.Modified)
+			deletions += len(summary.Destroyed)
+		}
+		buff.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", escapeMarkdown(file), additions, modifications, deletions))
+	}
+
+	for _, file := range group.SortedFileNames() {
+		responses := group[file]
+		responses.SortByReleaseName()
+		buff.WriteString(fmt.Sprintf("\n### %s\n", escapeMarkdown(file)))
+		for _, response := range responses {
+			buff.WriteString(s.release(response))
+		}
+	}
+
+	if _, err := s.writer.Write(buff.Bytes()); err != nil {
+		log.Error("error in MarkdownSummarizer when writing to ", s.writer)
+	}
+}
+
+func (s MarkdownSummarizer) release(response stevedore.Response) string {
+	buff := bytes.NewBufferString("\n<details>\n")
+	summary := response.Summary()
+	switch {
+	case response.Err != nil:
+		buff.WriteString(fmt.Sprintf("<summary>:x: <b>%s</b>: failed (%s)</summary>\n\n", response.ReleaseName, failureKind(response.Err)))
+		buff.WriteString(fence("", s.redactor.Redact(response.Err.Error())))
+	case !response.HasDiff:
+		buff.WriteString(fmt.Sprintf("<summary><b>%s</b>: no changes</summary>\n", response.ReleaseName))
+	default:
+		buff.WriteString(fmt.Sprintf("<summary><b>%s</b>: %d to add, %d to change, %d to destroy</summary>\n\n",
+			response.ReleaseName, len(summary.Added), len(summary.Modified), len(summary.Destroyed)))
+		var rows []string
+		rows = append(rows, groupAndSortResourcesByKind(summary.Added, nocolor, "+")...)
+		rows = append(rows, groupAndSortResourcesByKind(summary.Modified, nocolor, "~")...)
+		rows = append(rows, groupAndSortResourcesByKind(summary.Destroyed, nocolor, "-")...)
+		for _, row := range rows {
+			buff.WriteString(fmt.Sprintf("- `%s`\n", row))
+		}
+		buff.WriteString("\n")
+		buff.WriteString(fence("diff", colors.ReplaceAllString(s.redactor.Redact(response.Diff), "")))
+	}
+	buff.WriteString("</details>\n")
+	return buff.String()
+}
+
+// fence returns the content as fenced code block, with a fence longer than any backticks in the content
+func fence(language, content string) string {
+	backticks := "```"
+	for strings.Contains(content, backticks) {
+		backticks += "`"
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return fmt.Sprintf("%s%s\n%s%s\n\n", backticks, language, content, backticks)
+}
+
+func escapeMarkdown(value string) string {
+	return strings.NewReplacer("|", "\\|", "<", "&lt;", ">", "&gt;").Replace(value)
+}
+
+// summaryFile writes the summary of the responses to the file at path, in the given format
+type summaryFile struct {
+	fs     afero.Fs
+	action string
+	format string
+	path   string
+}
+
+func (file summaryFile) write(group stevedore.GroupedResponses, redactor stevedore.Redactor) error {
+	if file.format == "" {
+		return nil
+	}
+	f, err := file.fs.Create(file.path)
+	if err != nil {
+		return fmt.Errorf("error while creating summary file %s: %v", file.path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	MarkdownSummarizer{writer: f, action: file.action, redactor: redactor}.Display(group)
+	return nil
+}
@@ -0,0 +1,94 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/databus23/helm-diff/manifest"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func markdownResponses() stevedore.GroupedResponses {
+	return stevedore.Responses{
+		{
+			File:        "services/redis.yaml",
+			ReleaseName: "redis",
+			UpstallResponse: helm.UpstallResponse{
+				HasDiff:       true,
+				Diff:          "\x1b[33mdefault, redis, ConfigMap (v1) has been added:\x1b[0m\n+ password: s3cr3t\n",
+				ExistingSpecs: map[string]*manifest.MappingResult{},
+				NewSpecs: map[string]*manifest.MappingResult{
+					"default, redis, ConfigMap (v1)": {Name: "default, redis, ConfigMap (v1)", Kind: "ConfigMap"},
+				},
+			},
+		},
+		{
+			File:        "services/redis.yaml",
+			ReleaseName: "redis-cache",
+		},
+		{
+			File:        "services/postgres.yaml",
+			ReleaseName: "postgres",
+			Err:         fmt.Errorf("error when installing postgres:\npassword: s3cr3t\nfailed due to timeout"),
+		},
+	}.GroupByFile()
+}
+
+func TestMarkdownSummarizer_Display(t *testing.T) {
+	t.Run("should display file table and collapsible section per release", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		MarkdownSummarizer{writer: buffer, action: "plan"}.Display(markdownResponses())
+
+		output := buffer.String()
+		assert.Contains(t, output, "## Stevedore plan\n")
+		assert.Contains(t, output, "| services/postgres.yaml | 0 | 0 | 0 |\n| services/redis.yaml | 1 | 0 | 0 |\n")
+		assert.Contains(t, output, "<summary><b>redis</b>: 1 to add, 0 to change, 0 to destroy</summary>")
+		assert.Contains(t, output, "- `+ConfigMap/redis`")
+		assert.Contains(t, output, "<summary><b>redis-cache</b>: no changes</summary>")
+		assert.Contains(t, output, "<summary>:x: <b>postgres</b>: failed (error)</summary>")
+		assert.Contains(t, output, "```diff\ndefault, redis, ConfigMap (v1) has been added:\n+ password: s3cr3t\n```")
+	})
+
+	t.Run("should redact sensitive values in diff and errors", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		redactor := stevedore.NewRedactor(nil, stevedore.Substitute{"REDIS_PASSWORD": "s3cr3t"})
+
+		MarkdownSummarizer{writer: buffer, action: "plan", redactor: redactor}.Display(markdownResponses())
+
+		output := buffer.String()
+		assert.NotContains(t, output, "s3cr3t")
+		assert.Contains(t, output, "+ password: <redacted>")
+		assert.Contains(t, output, "error when installing postgres:\npassword: <redacted>\nfailed due to timeout")
+	})
+}
+
+func TestSummaryFile_write(t *testing.T) {
+	t.Run("should write markdown summary to the file", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		file := summaryFile{fs: fs, action: "plan", format: summaryMarkdown, path: "plan.md"}
+
+		err := file.write(markdownResponses(), stevedore.Redactor{})
+
+		require.NoError(t, err)
+		data, err := afero.ReadFile(fs, "plan.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "## Stevedore plan")
+	})
+
+	t.Run("should not write when summary format is not given", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		file := summaryFile{fs: fs, action: "plan", path: "plan.md"}
+
+		err := file.write(markdownResponses(), stevedore.Redactor{})
+
+		require.NoError(t, err)
+		exists, _ := afero.Exists(fs, "plan.md")
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,149 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"gopkg.in/yaml.v2"
+)
+
+// KindStevedorePlan represents the kind of PlanDocument
+const KindStevedorePlan = "StevedorePlan"
+
+// PlanDocumentVersion represents the version of PlanDocument,
+// it is incremented whenever a field is removed or its meaning is changed
+const PlanDocumentVersion = 1
+
+// output formats of the plan
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+var colors = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// PlanDocument represents the machine readable output of the plan
+type PlanDocument struct {
+	Kind     string               `json:"kind" yaml:"kind"`
+	Version  int                  `json:"version" yaml:"version"`
+	Context  string               `json:"context" yaml:"context"`
+	Releases []PlannedRelease     `json:"releases" yaml:"releases"`
+	Ignored  []PlanIgnoredRelease `json:"ignored" yaml:"ignored"`
+}
+
+// PlannedRelease represents the planned changes of a release
+type PlannedRelease struct {
+	File            string         `json:"file" yaml:"file"`
+	Name            string         `json:"name" yaml:"name"`
+	Chart           string         `json:"chart" yaml:"chart"`
+	ChartVersion    string         `json:"chartVersion" yaml:"chartVersion"`
+	PlannedRevision int32          `json:"plannedRevision" yaml:"plannedRevision"`
+	HasDiff         bool           `json:"hasDiff" yaml:"hasDiff"`
+	Added           []PlanResource `json:"added" yaml:"added"`
+	Modified        []PlanResource `json:"modified" yaml:"modified"`
+	Destroyed       []PlanResource `json:"destroyed" yaml:"destroyed"`
+	Diff            string         `json:"diff" yaml:"diff"`
+	Error           string         `json:"error" yaml:"error"`
+}
+
+// PlanResource represents a kubernetes resource changed by a release
+type PlanResource struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+}
+
+// PlanIgnoredRelease represents a release which is not planned as it is ignored
+type PlanIgnoredRelease struct {
+	Name   string `json:"name" yaml:"name"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// NewPlanDocument returns the PlanDocument for the given responses,
+// with releases sorted by file and name
+func NewPlanDocument(info Info, responses stevedore.Responses) PlanDocument {
+	document := PlanDocument{
+		Kind:     KindStevedorePlan,
+		Version:  PlanDocumentVersion,
+		Context:  info.Context.Name,
+		Releases: []PlannedRelease{},
+		Ignored:  []PlanIgnoredRelease{},
+	}
+
+	redactor := info.Redactor()
+	group := responses.GroupByFile()
+	for _, file := range group.SortedFileNames() {
+		fileResponses := group[file]
+		fileResponses.SortByReleaseName()
+		for _, response := range fileResponses {
+			document.Releases = append(document.Releases, newPlannedRelease(response, redactor))
+		}
+	}
+
+	for _, ignored := range info.Ignored {
+		document.Ignored = append(document.Ignored, PlanIgnoredRelease{Name: ignored.Name, Reason: ignored.Reason})
+	}
+	return document
+}
+
+func newPlannedRelease(response stevedore.Response, redactor stevedore.Redactor) PlannedRelease {
+	summary := response.Summary()
+	release := PlannedRelease{
+		File:            response.File,
+		Name:            response.ReleaseName,
+		Chart:           response.ChartName,
+		ChartVersion:    response.ChartVersion,
+		PlannedRevision: response.CurrentReleaseVersion,
+		HasDiff:         response.HasDiff,
+		Added:           newPlanResources(summary.Added),
+		Modified:        newPlanResources(summary.Modified),
+		Destroyed:       newPlanResources(summary.Destroyed),
+		Diff:            colors.ReplaceAllString(redactor.Redact(response.Diff), ""),
+	}
+	if response.Err != nil {
+		release.Error = redactor.Redact(response.Err.Error())
+	}
+	return release
+}
+
+func newPlanResources(resources helm.Resources) []PlanResource {
+	result := make([]PlanResource, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, PlanResource{Kind: resource.Kind, Name: resource.Name})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Write writes the document to the writer in the given format
+func (document PlanDocument) Write(writer io.Writer, format string) error {
+	var data []byte
+	var err error
+	switch format {
+	case outputJSON:
+		data, err = json.MarshalIndent(document, "", "  ")
+		data = append(data, '\n')
+	case outputYAML:
+		data, err = yaml.Marshal(document)
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats are %s and %s", format, outputJSON, outputYAML)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+func isValidOutputFormat(format string) bool {
+	return format == "" || format == outputJSON || format == outputYAML
+}
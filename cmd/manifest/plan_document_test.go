@@ -0,0 +1,145 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/databus23/helm-diff/manifest"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlanDocument(t *testing.T) {
+	info := Info{
+		Context: stevedore.Context{Name: "components"},
+		Ignored: stevedore.IgnoredReleases{{Name: "x-stevedore", Reason: "not ready"}},
+	}
+	responses := stevedore.Responses{
+		{
+			File:        "services/postgres.yaml",
+			ReleaseName: "postgres",
+			Err:         fmt.Errorf("error when installing postgres due to timeout"),
+		},
+		{
+			File:                  "services/redis.yaml",
+			ReleaseName:           "redis",
+			ChartName:             "stable/redis",
+			ChartVersion:          "10.0.0",
+			CurrentReleaseVersion: 3,
+			UpstallResponse: helm.UpstallResponse{
+				HasDiff: true,
+				Diff:    "\x1b[33mdefault, redis, Service (v1) has been added:\x1b[0m\n",
+				NewSpecs: map[string]*manifest.MappingResult{
+					"default, redis, Service (v1)":          {Name: "default, redis, Service (v1)", Kind: "Service"},
+					"default, redis, StatefulSet (apps)":    {Name: "default, redis, StatefulSet (apps)", Kind: "StatefulSet"},
+					"default, redis-headless, Service (v1)": {Name: "default, redis-headless, Service (v1)", Kind: "Service"},
+				},
+			},
+		},
+		{
+			File:        "services/postgres.yaml",
+			ReleaseName: "pgbouncer",
+		},
+	}
+
+	document := NewPlanDocument(info, responses)
+
+	expected := PlanDocument{
+		Kind:    KindStevedorePlan,
+		Version: PlanDocumentVersion,
+		Context: "components",
+		Releases: []PlannedRelease{
+			{
+				File:      "services/postgres.yaml",
+				Name:      "pgbouncer",
+				Added:     []PlanResource{},
+				Modified:  []PlanResource{},
+				Destroyed: []PlanResource{},
+			},
+			{
+				File:      "services/postgres.yaml",
+				Name:      "postgres",
+				Added:     []PlanResource{},
+				Modified:  []PlanResource{},
+				Destroyed: []PlanResource{},
+				Error:     "error when installing postgres due to timeout",
+			},
+			{
+				File:            "services/redis.yaml",
+				Name:            "redis",
+				Chart:           "stable/redis",
+				ChartVersion:    "10.0.0",
+				PlannedRevision: 3,
+				HasDiff:         true,
+				Added: []PlanResource{
+					{Kind: "Service", Name: "redis"},
+					{Kind: "Service", Name: "redis-headless"},
+					{Kind: "StatefulSet", Name: "redis"},
+				},
+				Modified:  []PlanResource{},
+				Destroyed: []PlanResource{},
+				Diff:      "default, redis, Service (v1) has been added:\n",
+			},
+		},
+		Ignored: []PlanIgnoredRelease{{Name: "x-stevedore", Reason: "not ready"}},
+	}
+	assert.Equal(t, expected, document)
+}
+
+func TestPlanDocument_Write(t *testing.T) {
+	document := PlanDocument{
+		Kind:     KindStevedorePlan,
+		Version:  PlanDocumentVersion,
+		Context:  "components",
+		Releases: []PlannedRelease{{File: "redis.yaml", Name: "redis", Added: []PlanResource{{Kind: "Service", Name: "redis"}}}},
+		Ignored:  []PlanIgnoredRelease{},
+	}
+
+	t.Run("should write as json", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := document.Write(buffer, "json")
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+  "kind": "StevedorePlan",
+  "version": 1,
+  "context": "components",
+  "releases": [
+    {
+      "file": "redis.yaml",
+      "name": "redis",
+      "chart": "",
+      "chartVersion": "",
+      "plannedRevision": 0,
+      "hasDiff": false,
+      "added": [{"kind": "Service", "name": "redis"}],
+      "modified": null,
+      "destroyed": null,
+      "diff": "",
+      "error": ""
+    }
+  ],
+  "ignored": []
+}`, buffer.String())
+	})
+
+	t.Run("should write as yaml", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		err := document.Write(buffer, "yaml")
+
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "kind: StevedorePlan\nversion: 1\ncontext: components\n")
+		assert.Contains(t, buffer.String(), "plannedRevision: 0")
+	})
+
+	t.Run("should fail for unknown format", func(t *testing.T) {
+		err := document.Write(&bytes.Buffer{}, "xml")
+
+		assert.EqualError(t, err, "invalid output format xml, supported formats are json and yaml")
+	})
+}
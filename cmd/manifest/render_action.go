@@ -4,12 +4,17 @@ import (
 	"io"
 
 	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"gopkg.in/yaml.v2"
 )
 
 // RenderAction to render manifest
 type RenderAction struct {
-	info Info
-	out  io.Writer
+	info    Info
+	out     io.Writer
+	explain bool
+	charts  helm.ChartLocator
 }
 
 // Do RenderAction to render manifest
@@ -19,15 +24,20 @@ func (action RenderAction) Do() (Info, error) {
 		out = cli.OutputStream()
 	}
 
+	redactor := action.info.Redactor()
+	var valuesErrors stevedore.ValuesErrors
+	unavailableCharts := map[string]bool{}
 	for _, manifestFile := range action.info.ManifestFiles {
 		cli.FPrintYaml(out, map[string]interface{}{"File": manifestFile.File})
 
 		cli.FPrintYaml(out, map[string]interface{}{"DeployTo": manifestFile.Manifest.DeployTo})
 
 		for _, releaseSpecification := range manifestFile.Manifest.Spec {
+			valuesErrors = append(valuesErrors, action.validate(releaseSpecification, unavailableCharts)...)
 			overrides := releaseSpecification.Release.Overrides()
-			substitute := releaseSpecification.SubstitutedVariables()
+			substitute := redactor.RedactSubstitute(releaseSpecification.SubstitutedVariables())
 
+			releaseSpecification.Release.Values = redactor.RedactReleaseValues(releaseSpecification)
 			cli.FPrintYaml(out, map[string]interface{}{"Manifest": releaseSpecification})
 
 			if len(overrides.Spec) != 0 {
@@ -37,7 +47,66 @@ func (action RenderAction) Do() (Info, error) {
 			if len(substitute) != 0 {
 				cli.FPrintYaml(out, map[string]interface{}{"Used following variables": substitute})
 			}
+
+			if providers := releaseSpecification.VariableProviders(); len(providers) != 0 {
+				cli.FPrintYaml(out, map[string]interface{}{"Used variables from providers": providers})
+			}
+
+			if action.explain {
+				cli.FPrintYaml(out, map[string]interface{}{"Explain": explain(releaseSpecification.Release.Explain(action.info.EnvSources))})
+			}
 		}
 	}
+
+	if len(valuesErrors) != 0 {
+		return action.info, valuesErrors
+	}
 	return action.info, nil
 }
+
+// validate validates the values of the release against its chart, warns about the unknown keys and returns the invalid ones.
+// Charts which are not available locally are warned about once, and recorded in unavailableCharts
+func (action RenderAction) validate(releaseSpecification stevedore.ReleaseSpecification, unavailableCharts map[string]bool) stevedore.ValuesErrors {
+	if action.charts == nil {
+		return nil
+	}
+
+	valuesErrors, err := releaseSpecification.ValidateValues(action.charts)
+	if notFound, ok := err.(helm.ChartNotFoundError); ok {
+		if !unavailableCharts[notFound.Error()] {
+			unavailableCharts[notFound.Error()] = true
+			cli.Warnf("%v, unable to validate the values of the releases using it", notFound)
+		}
+		return nil
+	}
+	if err != nil {
+		cli.Warnf("unable to validate the values of release %s: %v", releaseSpecification.Release.Name, err)
+		return nil
+	}
+
+	var result stevedore.ValuesErrors
+	for _, valuesError := range valuesErrors {
+		if valuesError.Unknown {
+			cli.Warn(valuesError.Error())
+			continue
+		}
+		result = append(result, valuesError)
+	}
+	return result
+}
+
+func explain(explanations stevedore.Explanations) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, explanation := range explanations {
+		sources := []string{explanation.Source.String()}
+		for _, variable := range explanation.Variables {
+			sources = append(sources, variable.String())
+		}
+		item := yaml.MapSlice{{Key: "value", Value: explanation.Value}, {Key: "source", Value: sources[0]}}
+		if len(sources) > 1 {
+			item = append(item, yaml.MapItem{Key: "variables", Value: sources[1:]})
+		}
+		result = append(result, yaml.MapItem{Key: explanation.Path, Value: item})
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package manifest
+
+import (
+	"context"
+
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+)
+
+// RollbackAction to roll back releases in manifest
+type RollbackAction struct {
+	info        Info
+	client      helm.Client
+	dryRun      bool
+	helmTimeout int64
+}
+
+// NewRollbackAction returns RollbackAction with given arguments
+func NewRollbackAction(info Info, client helm.Client, dryRun bool, helmTimeout int64) RollbackAction {
+	return RollbackAction{info, client, dryRun, helmTimeout}
+}
+
+// Do will roll back releases in manifests, or show the changes to be made on dry run
+func (action RollbackAction) Do() (Info, error) {
+	opts := stevedore.Opts{DryRun: action.dryRun, Filter: action.dryRun}
+	responses, err := stevedore.CreateRollbackResponse(context.TODO(), action.client, action.info.ManifestFiles, opts, action.helmTimeout)
+	if err != nil {
+		return Info{}, err
+	}
+	if len(responses) == 0 {
+		cli.Warn("No changes to roll back")
+		return Info{}, nil
+	}
+
+	handler := newReleaseResponseHandler(action.dryRun, "Rolled back Successfully", "No changes to roll back")
+	return display(action.info, responses, handler)
+}
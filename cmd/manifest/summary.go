@@ -45,6 +45,7 @@ type TableSummarizer struct {
 func (s TableSummarizer) Display(group stevedore.GroupedResponses) {
 	changedFilesTable := createTable(s.writer, []string{"FILENAME", "ADDITIONS", "MODIFICATIONS", "DESTRUCTIONS"}, false)
 	helmReleaseTable := createTable(s.writer, []string{"RELEASE", "MANIFEST CHANGES"}, true)
+	failedReleasesTable := createTable(s.writer, []string{"RELEASE", "FAILURE", "ERROR"}, true)
 
 	for _, file := range group.SortedFileNames() {
 		responses := group[file]
@@ -68,6 +69,11 @@ func (s TableSummarizer) Display(group stevedore.GroupedResponses) {
 				releaseDetail := nocolor("%s\n(%s)", response.ReleaseName, fileName)
 				helmReleaseTable.Append(append([]string{releaseDetail}, formatRelease(summary)))
 			}
+
+			if response.Err != nil {
+				releaseDetail := nocolor("%s\n(%s)", response.ReleaseName, fileName)
+				failedReleasesTable.Append([]string{releaseDetail, red("%s", failureKind(response.Err)), response.Err.Error()})
+			}
 		}
 
 		if fileHasDiff {
@@ -82,7 +88,25 @@ func (s TableSummarizer) Display(group stevedore.GroupedResponses) {
 
 	renderTable(s.writer, "Release changes:", helmReleaseTable)
 	renderTable(s.writer, "File changes:", changedFilesTable)
+	renderTable(s.writer, "Failed releases:", failedReleasesTable)
+
+}
 
+// DisplayOrphans outputs the releases which are no longer declared in manifests to the TableSummarizer.writer
+func (s TableSummarizer) DisplayOrphans(orphans stevedore.OrphanedReleases) {
+	orphanedReleasesTable := createTable(s.writer, []string{"RELEASE", "NAMESPACE"}, false)
+	for _, orphan := range orphans {
+		orphanedReleasesTable.Append([]string{red("-%s", orphan.Name), orphan.Namespace})
+	}
+	renderTable(s.writer, "Orphaned releases:", orphanedReleasesTable)
+}
+
+// failureKind returns the stage at which interacting with helm has failed, if known
+func failureKind(err error) string {
+	if failure, ok := err.(helm.Failure); ok {
+		return failure.Kind().String()
+	}
+	return "error"
 }
 
 func getFormattedFileName(file string) string {
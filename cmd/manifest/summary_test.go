@@ -0,0 +1,55 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableSummarizer_Display(t *testing.T) {
+	t.Run("should display failed releases along with the kind of failure", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		group := stevedore.GroupedResponses{
+			"services/redis.yaml": stevedore.Responses{
+				{
+					File:        "services/redis.yaml",
+					ReleaseName: "redis",
+					Err:         helm.ChartLocationError{ReleaseName: "redis", ChartName: "stable/redis", Err: fmt.Errorf("chart not found")},
+				},
+				{
+					File:        "services/redis.yaml",
+					ReleaseName: "redis-cache",
+					Err:         fmt.Errorf("some error"),
+				},
+				{
+					File:        "services/redis.yaml",
+					ReleaseName: "redis-queue",
+				},
+			},
+		}
+
+		TableSummarizer{writer: buffer}.Display(group)
+
+		output := buffer.String()
+		assert.Contains(t, output, "Failed releases:")
+		assert.Contains(t, output, "chart location")
+		assert.Contains(t, output, "chart not found")
+		assert.Contains(t, output, "redis-cache")
+		assert.NotContains(t, output, "redis-queue")
+	})
+
+	t.Run("should not display failed releases when there are no errors", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		group := stevedore.GroupedResponses{
+			"redis.yaml": stevedore.Responses{{File: "redis.yaml", ReleaseName: "redis"}},
+		}
+
+		TableSummarizer{writer: buffer}.Display(group)
+
+		assert.NotContains(t, buffer.String(), "Failed releases:")
+	})
+}
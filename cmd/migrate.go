@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateEnvsPath      string
+	migrateOverridesPath string
+	migrateManifestPath  string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate stevedore yaml(s) to the latest version",
+	Long: `Migrate stevedore yaml(s) of the older versions to the latest version and save it back.
+Yaml(s) which are already in the latest version are left untouched`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var all fileContents
+		for _, fetch := range getFetchers(migrateManifestPath, migrateOverridesPath, migrateEnvsPath) {
+			contents, err := fetch(fs)
+			if err != nil {
+				return err
+			}
+			all = append(all, contents...)
+		}
+		return migrate(fs, cli.OutputStream(), all...)
+	},
+}
+
+// migrate saves the contents which are decoded from an older version of their kind
+func migrate(fs afero.Fs, writer io.Writer, contents ...fileContent) error {
+	var outdated fileContents
+	for _, content := range contents {
+		if !stevedore.IsCurrentVersion(content.kind, content.version) {
+			outdated = append(outdated, content)
+		}
+	}
+
+	if len(outdated) == 0 {
+		_, _ = fmt.Fprintln(writer, "all the yaml(s) are in the latest version")
+		return nil
+	}
+	return save(fs, migrated(writer), outdated...)
+}
+
+func init() {
+	migrateCmd.PersistentFlags().StringVarP(&migrateManifestPath, "manifests-path", "f", "", "Stevedore manifest(s) path (can be yaml file or folder)")
+	migrateCmd.PersistentFlags().StringVarP(&migrateEnvsPath, "envs-path", "e", "", "Stevedore env(s) path (can be yaml file or folder)")
+	migrateCmd.PersistentFlags().StringVarP(&migrateOverridesPath, "overrides-path", "o", "", "Stevedore overrides path (can be yaml file or folder)")
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("should save only the contents decoded from an older version", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		_ = afero.WriteFile(fs, "/mock/current.yaml", []byte("kind: StevedoreOverride\nversion: \"2\"\nspec: []\n"), 0644)
+		_ = afero.WriteFile(fs, "/mock/outdated.yaml", []byte("kind: StevedoreOverride\nversion: \"1\"\nspec: []\n"), 0644)
+		overrides := stevedore.Overrides{Kind: stevedore.KindStevedoreOverride, Version: stevedore.OverrideCurrentVersion, Spec: stevedore.OverrideSpecifications{}}
+		contents := fileContents{
+			{fileName: "/mock/current.yaml", kind: stevedore.KindStevedoreOverride, version: "2", content: overrides},
+			{fileName: "/mock/outdated.yaml", kind: stevedore.KindStevedoreOverride, version: "1", content: overrides},
+		}
+		out := &bytes.Buffer{}
+
+		err := migrate(fs, out, contents...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "migrated /mock/outdated.yaml from version 1 to 2\n", out.String())
+		current, _ := afero.ReadFile(fs, "/mock/current.yaml")
+		assert.Equal(t, "kind: StevedoreOverride\nversion: \"2\"\nspec: []\n", string(current))
+		outdated, _ := afero.ReadFile(fs, "/mock/outdated.yaml")
+		assert.Equal(t, "kind: StevedoreOverride\nversion: \"2\"\nspec: []\n", string(outdated))
+	})
+
+	t.Run("should convert and save the outdated yaml(s) fetched from the path", func(t *testing.T) {
+		// version 1 of the overrides declared the override specifications as overrides, instead of spec
+		stevedore.RegisterConverter(stevedore.Converter{
+			Kind: stevedore.KindStevedoreOverride,
+			From: "1",
+			To:   "2",
+			Convert: func(document stevedore.Document) (stevedore.Document, error) {
+				document["spec"] = document["overrides"]
+				delete(document, "overrides")
+				return document, nil
+			},
+		})
+		fs := afero.NewMemMapFs()
+		current := "kind: StevedoreOverride\nversion: \"2\"\nspec: []\n"
+		_ = afero.WriteFile(fs, "/mock/overrides/current.yaml", []byte(current), 0644)
+		outdated := `kind: StevedoreOverride
+version: "1"
+overrides:
+- matches:
+    applicationName: x-service
+  values:
+    replicas: 2
+`
+		_ = afero.WriteFile(fs, "/mock/overrides/outdated.yaml", []byte(outdated), 0644)
+		var contents fileContents
+		for _, fetch := range getFetchers("", "/mock/overrides", "") {
+			fetched, err := fetch(fs)
+			assert.NoError(t, err)
+			contents = append(contents, fetched...)
+		}
+		out := &bytes.Buffer{}
+
+		err := migrate(fs, out, contents...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "migrated /mock/overrides/outdated.yaml from version 1 to 2\n", out.String())
+		actualCurrent, _ := afero.ReadFile(fs, "/mock/overrides/current.yaml")
+		assert.Equal(t, current, string(actualCurrent))
+		actualOutdated, _ := afero.ReadFile(fs, "/mock/overrides/outdated.yaml")
+		expected := `kind: StevedoreOverride
+version: "2"
+spec:
+- matches:
+    applicationName: x-service
+  values:
+    replicas: 2
+`
+		assert.Equal(t, expected, string(actualOutdated))
+	})
+
+	t.Run("should not report the yaml(s) which could not be saved as migrated", func(t *testing.T) {
+		overrides := stevedore.Overrides{Kind: stevedore.KindStevedoreOverride, Version: stevedore.OverrideCurrentVersion, Spec: stevedore.OverrideSpecifications{}}
+		contents := fileContents{{fileName: "/mock/outdated.yaml", kind: stevedore.KindStevedoreOverride, version: "1", content: overrides}}
+		out := &bytes.Buffer{}
+
+		err := migrate(afero.NewReadOnlyFs(afero.NewMemMapFs()), out, contents...)
+
+		assert.Error(t, err)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("should not save anything when all the contents are in the latest version", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		contents := fileContents{{fileName: "/mock/current.yaml", kind: stevedore.KindStevedoreEnv, version: "2"}}
+		out := &bytes.Buffer{}
+
+		err := migrate(fs, out, contents...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "all the yaml(s) are in the latest version\n", out.String())
+		exists, _ := afero.Exists(fs, "/mock/current.yaml")
+		assert.False(t, exists)
+	})
+}
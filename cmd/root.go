@@ -17,7 +17,7 @@ var cfgFile string
 var logLevel string
 var fs = afero.NewOsFs()
 
-var pluginLoadingCommands = []string{"apply", "plan", "render", "server", "init", "plugin", "dependency"}
+var pluginLoadingCommands = []string{"apply", "plan", "render", "destroy", "rollback", "server", "init", "plugin", "dependency"}
 
 var rootCmd = &cobra.Command{
 	Use:   "stevedore",
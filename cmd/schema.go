@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gojek/stevedore/cmd/cli"
+	"github.com/gojek/stevedore/pkg/schema"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutputDir string
+
+var schemaCmd = &cobra.Command{
+	Use:   fmt.Sprintf("schema [%s]", strings.Join(schema.All().Names(), "|")),
+	Short: "Generate JSON Schema for stevedore yaml(s)",
+	Long: `Generate JSON Schema for stevedore yaml(s), which editors can use for completion and validation.
+Prints the schema of the given yaml to stdout, or writes the schema of all the yaml(s) to --output-dir`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		documents, err := schemaDocuments(args)
+		if err != nil {
+			return err
+		}
+		if schemaOutputDir == "" {
+			if len(documents) != 1 {
+				return fmt.Errorf("provide one of %s to print, or the directory to write all using --output-dir", strings.Join(schema.All().Names(), ", "))
+			}
+			return printSchema(cli.OutputStream(), documents[0])
+		}
+		return writeSchemas(fs, schemaOutputDir, documents)
+	},
+}
+
+func schemaDocuments(names []string) (schema.Documents, error) {
+	all := schema.All()
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	document, ok := all.Find(names[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %s. Known schemas are %s", names[0], strings.Join(all.Names(), ", "))
+	}
+	return schema.Documents{document}, nil
+}
+
+func printSchema(writer io.Writer, document schema.Document) error {
+	data, err := document.JSON()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+func writeSchemas(fs afero.Fs, dir string, documents schema.Documents) error {
+	if err := fs.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, document := range documents {
+		data, err := document.JSON()
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Join(dir, document.FileName())
+		if err := afero.WriteFile(fs, fileName, data, 0644); err != nil {
+			return fmt.Errorf("unable to write schema %s, reason: %v", fileName, err)
+		}
+		cli.Info(fmt.Sprintf("written %s", fileName))
+	}
+	return nil
+}
+
+func init() {
+	schemaCmd.PersistentFlags().StringVar(&schemaOutputDir, "output-dir", "", "Directory to write the schema(s) to")
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -20,6 +20,11 @@ func Debug(args ...interface{}) {
 	logger.Debug(args...)
 }
 
+// Warn logs warn level logs
+func Warn(args ...interface{}) {
+	logger.Warn(args...)
+}
+
 // Error logs error level logs
 func Error(args ...interface{}) {
 	logger.Error(args...)
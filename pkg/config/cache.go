@@ -0,0 +1,193 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultWorkers is the number of fetches run concurrently, unless given
+const DefaultWorkers = 4
+
+// DiskCache stores the fetched configs as files in Dir, which are used until they are older than TTL
+type DiskCache struct {
+	Fs  afero.Fs
+	Dir string
+	TTL time.Duration
+}
+
+// Cache memoizes the configs fetched by each provider for a context and data, such that each of them is fetched
+// once in a run. The configs of the cacheable providers are stored in the disk cache as well, if given.
+// It also keeps track of the messages about the configs reported in the run, so that each of them is reported once
+type Cache struct {
+	workers  int
+	disk     *DiskCache
+	mutex    sync.Mutex
+	entries  map[string]*cacheEntry
+	reported sync.Map
+}
+
+type cacheEntry struct {
+	once          sync.Once
+	result        map[string]interface{}
+	sensitiveKeys []string
+	err           error
+}
+
+// NewCache returns a Cache which runs at most the given number of fetches concurrently
+func NewCache(workers int, disk *DiskCache) *Cache {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+	return &Cache{workers: workers, disk: disk, entries: map[string]*cacheEntry{}}
+}
+
+// firstReport returns true if the message is not already reported in the run, marking it as reported
+func (cache *Cache) firstReport(message string) bool {
+	_, reported := cache.reported.LoadOrStore(message, true)
+	return !reported
+}
+
+// fetch returns the memoized configs of the provider along with their sensitive keys, fetching them if they are not
+// already fetched. Concurrent fetches of the same configs wait for the first one
+func (cache *Cache) fetch(impl ProviderImpl, ctx map[string]string, data interface{}) (map[string]interface{}, []string, error) {
+	key, err := cacheKey(impl.Name, impl.manifestDir, ctx, data)
+	if err != nil {
+		return impl.fetchSensitiveKeys(ctx, data)
+	}
+
+	cache.mutex.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		cache.entries[key] = entry
+	}
+	cache.mutex.Unlock()
+
+	entry.once.Do(func() {
+		store := cache.disk != nil && impl.cacheable()
+		if store {
+			if result, ok := cache.disk.load(key); ok {
+				entry.result = result
+				return
+			}
+		}
+		entry.result, entry.sensitiveKeys, entry.err = impl.fetchSensitiveKeys(ctx, data)
+		if store && entry.err == nil {
+			cache.disk.save(key, entry.result)
+		}
+	})
+	return entry.result, entry.sensitiveKeys, entry.err
+}
+
+// cacheKey returns the digest of the name of the provider, the directory of the manifest it is relative to, the context and the data
+func cacheKey(name, manifestDir string, ctx map[string]string, data interface{}) (string, error) {
+	keys := make([]string, 0, len(ctx))
+	for key := range ctx {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, ctx[key]))
+	}
+
+	content, err := yaml.Marshal(map[string]interface{}{"provider": name, "manifestDir": manifestDir, "context": pairs, "data": data})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+func (disk DiskCache) path(key string) string {
+	return filepath.Join(disk.Dir, fmt.Sprintf("%s.yaml", key))
+}
+
+// load returns the configs stored for the key, unless they are older than TTL
+func (disk DiskCache) load(key string) (map[string]interface{}, bool) {
+	info, err := disk.Fs.Stat(disk.path(key))
+	if err != nil || time.Since(info.ModTime()) > disk.TTL {
+		return nil, false
+	}
+	content, err := afero.ReadFile(disk.Fs, disk.path(key))
+	if err != nil {
+		return nil, false
+	}
+	result := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &result); err != nil {
+		return nil, false
+	}
+	return result, true
+}
+
+// save stores the configs for the key. Failures are ignored, as the configs are fetched again on the next run
+func (disk DiskCache) save(key string, result map[string]interface{}) {
+	content, err := yaml.Marshal(result)
+	if err != nil {
+		return
+	}
+	if err := disk.Fs.MkdirAll(disk.Dir, 0700); err != nil {
+		return
+	}
+	_ = afero.WriteFile(disk.Fs, disk.path(key), content, 0600)
+}
+
+// Request represents the configs to be fetched using the providers for the stevedore context
+type Request struct {
+	Providers Providers
+	Context   map[string]string
+	Data      map[string]interface{}
+}
+
+// Prefetch fetches the configs of the requests concurrently using the workers of the cache of the providers,
+// so that the later fetches of the same configs are served from the cache, along with the failures.
+// Requests for the providers without a cache are skipped
+func Prefetch(requests []Request) {
+	type task struct {
+		impl ProviderImpl
+		ctx  map[string]string
+		data interface{}
+	}
+
+	var tasks []task
+	workers := 0
+	for _, request := range requests {
+		pluginsMap := request.Providers.byName()
+		for rootKey, configs := range request.Data {
+			fetcher, ok := pluginsMap[rootKey]
+			if !ok || fetcher.cache == nil {
+				continue
+			}
+			if workers == 0 {
+				workers = fetcher.cache.workers
+			}
+			tasks = append(tasks, task{impl: fetcher, ctx: fetcher.context(request.Context), data: configs})
+		}
+	}
+	if len(tasks) == 0 {
+		return
+	}
+
+	queue := make(chan task)
+	var wait sync.WaitGroup
+	for index := 0; index < workers && index < len(tasks); index++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for each := range queue {
+				_, _, _ = each.impl.fetch(each.ctx, each.data)
+			}
+		}()
+	}
+	for _, each := range tasks {
+		queue <- each
+	}
+	close(queue)
+	wait.Wait()
+}
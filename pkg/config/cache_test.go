@@ -0,0 +1,240 @@
+package config_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gojek/stevedore/pkg/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingProvider returns the data as the config, counting the fetches and the maximum concurrent fetches
+type countingProvider struct {
+	mutex     sync.Mutex
+	calls     int
+	running   int
+	maxActive int
+	delay     time.Duration
+	sensitive bool
+	cacheable bool
+	err       error
+}
+
+func (p *countingProvider) Fetch(context map[string]string, data interface{}) (map[string]interface{}, error) {
+	p.mutex.Lock()
+	p.calls++
+	p.running++
+	if p.running > p.maxActive {
+		p.maxActive = p.running
+	}
+	p.mutex.Unlock()
+
+	time.Sleep(p.delay)
+
+	p.mutex.Lock()
+	p.running--
+	p.mutex.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return map[string]interface{}{"value": fmt.Sprintf("%v-%s", data, context["environment"])}, nil
+}
+
+func (p *countingProvider) Sensitive() bool {
+	return p.sensitive
+}
+
+func (p *countingProvider) Cacheable() bool {
+	return p.cacheable
+}
+
+// relativeProvider returns the directory of the manifest which it is relative to as the config
+type relativeProvider struct {
+	*countingProvider
+	manifestDir string
+}
+
+func (p relativeProvider) RelativeTo(manifestDir string) config.Provider {
+	return relativeProvider{countingProvider: p.countingProvider, manifestDir: manifestDir}
+}
+
+func (p relativeProvider) Fetch(context map[string]string, data interface{}) (map[string]interface{}, error) {
+	if _, err := p.countingProvider.Fetch(context, data); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"dir": p.manifestDir, "context": context}, nil
+}
+
+func TestProvidersRelativeTo(t *testing.T) {
+	stevedoreCtx := map[string]string{"environment": "staging"}
+
+	t.Run("should resolve the configs of the relative providers against the directory of the manifest", func(t *testing.T) {
+		provider := relativeProvider{countingProvider: &countingProvider{}}
+		providers := config.Providers{{Name: "file", Provider: provider}}.WithCache(config.NewCache(2, nil))
+
+		first, err := providers.RelativeTo("/app/x").Fetch(stevedoreCtx, map[string]interface{}{"file": ".env"})
+		assert.NoError(t, err)
+		_, err = providers.RelativeTo("/app/x").Fetch(stevedoreCtx, map[string]interface{}{"file": ".env"})
+		assert.NoError(t, err)
+		second, err := providers.RelativeTo("/app/y").Fetch(stevedoreCtx, map[string]interface{}{"file": ".env"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"dir": "/app/x", "context": stevedoreCtx}, first["file"])
+		assert.Equal(t, map[string]interface{}{"dir": "/app/y", "context": stevedoreCtx}, second["file"])
+		assert.Equal(t, 2, provider.calls)
+	})
+}
+
+func TestProvidersFetchWithCache(t *testing.T) {
+	stevedoreCtx := map[string]string{"environment": "staging"}
+
+	t.Run("should fetch once for the same provider, context and data", func(t *testing.T) {
+		provider := &countingProvider{}
+		providers := config.Providers{{Name: "store", Provider: provider}}.WithCache(config.NewCache(2, nil))
+
+		first, err := providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+		assert.NoError(t, err)
+		second, err := providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+		assert.NoError(t, err)
+		_, err = providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "y-service"})
+		assert.NoError(t, err)
+		_, err = providers.Fetch(map[string]string{"environment": "production"}, map[string]interface{}{"store": "x-service"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, map[string]interface{}{"value": "x-service-staging"}, first["store"])
+		assert.Equal(t, 3, provider.calls)
+	})
+
+	t.Run("should fetch every time without cache", func(t *testing.T) {
+		provider := &countingProvider{}
+		providers := config.Providers{{Name: "store", Provider: provider}}
+
+		_, _ = providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+		_, _ = providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+
+		assert.Equal(t, 2, provider.calls)
+	})
+
+	t.Run("should memoize the failures", func(t *testing.T) {
+		provider := &countingProvider{err: fmt.Errorf("connection refused")}
+		providers := config.Providers{{Name: "store", Provider: provider}}.WithCache(config.NewCache(2, nil))
+
+		_, err := providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+		assert.EqualError(t, err, "error in fetching from provider: connection refused")
+		_, err = providers.Fetch(stevedoreCtx, map[string]interface{}{"store": "x-service"})
+		assert.EqualError(t, err, "error in fetching from provider: connection refused")
+
+		assert.Equal(t, 1, provider.calls)
+	})
+}
+
+func TestProvidersFirstReport(t *testing.T) {
+	t.Run("should report a message once in the run of the cache", func(t *testing.T) {
+		providers := config.Providers{{Name: "store", Provider: &countingProvider{}}}.WithCache(config.NewCache(2, nil))
+
+		assert.True(t, providers.FirstReport("conflicting config name"))
+		assert.False(t, providers.FirstReport("conflicting config name"))
+		assert.False(t, providers.RelativeTo("/app/x").FirstReport("conflicting config name"))
+		assert.True(t, providers.FirstReport("conflicting config type"))
+
+		nextRun := config.Providers{{Name: "store", Provider: &countingProvider{}}}.WithCache(config.NewCache(2, nil))
+		assert.True(t, nextRun.FirstReport("conflicting config name"))
+	})
+
+	t.Run("should report a message every time without cache", func(t *testing.T) {
+		providers := config.Providers{{Name: "store", Provider: &countingProvider{}}}
+
+		assert.True(t, providers.FirstReport("conflicting config name"))
+		assert.True(t, providers.FirstReport("conflicting config name"))
+	})
+}
+
+func TestPrefetch(t *testing.T) {
+	stevedoreCtx := map[string]string{"environment": "staging"}
+
+	t.Run("should fetch the requests concurrently using at most the given workers", func(t *testing.T) {
+		provider := &countingProvider{delay: 10 * time.Millisecond}
+		providers := config.Providers{{Name: "store", Provider: provider}}.WithCache(config.NewCache(2, nil))
+		var requests []config.Request
+		for index := 0; index < 6; index++ {
+			requests = append(requests, config.Request{Providers: providers, Context: stevedoreCtx, Data: map[string]interface{}{"store": index % 4}})
+		}
+
+		config.Prefetch(requests)
+		actual, err := providers.Fetch(stevedoreCtx, map[string]interface{}{"store": 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"value": "3-staging"}, actual["store"])
+		assert.Equal(t, 4, provider.calls)
+		assert.Equal(t, 2, provider.maxActive)
+	})
+
+	t.Run("should skip the providers without cache", func(t *testing.T) {
+		provider := &countingProvider{}
+		providers := config.Providers{{Name: "store", Provider: provider}}
+
+		config.Prefetch([]config.Request{{Providers: providers, Context: stevedoreCtx, Data: map[string]interface{}{"store": "x-service"}}})
+
+		assert.Equal(t, 0, provider.calls)
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	stevedoreCtx := map[string]string{"environment": "staging"}
+	data := map[string]interface{}{"store": "x-service"}
+
+	t.Run("should reuse the configs cached on disk until the ttl", func(t *testing.T) {
+		memFs := afero.NewMemMapFs()
+		disk := &config.DiskCache{Fs: memFs, Dir: "/cache", TTL: time.Hour}
+		provider := &countingProvider{cacheable: true}
+		providers := config.Providers{{Name: "store", Provider: provider}}
+
+		_, err := providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, data)
+		assert.NoError(t, err)
+		actual, err := providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"value": "x-service-staging"}, actual["store"])
+		assert.Equal(t, 1, provider.calls)
+
+		files, _ := afero.ReadDir(memFs, "/cache")
+		if assert.Len(t, files, 1) {
+			expired := time.Now().Add(-2 * time.Hour)
+			_ = memFs.Chtimes("/cache/"+files[0].Name(), expired, expired)
+		}
+		_, err = providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.calls)
+	})
+
+	t.Run("should not cache the configs of the sensitive providers on disk", func(t *testing.T) {
+		memFs := afero.NewMemMapFs()
+		disk := &config.DiskCache{Fs: memFs, Dir: "/cache", TTL: time.Hour}
+		provider := &countingProvider{sensitive: true, cacheable: true}
+		providers := config.Providers{{Name: "vault", Provider: provider}}
+
+		_, _ = providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, map[string]interface{}{"vault": "secret/x-service"})
+		_, _ = providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, map[string]interface{}{"vault": "secret/x-service"})
+
+		exists, _ := afero.DirExists(memFs, "/cache")
+		assert.False(t, exists)
+		assert.Equal(t, 2, provider.calls)
+	})
+	t.Run("should not cache the configs of the providers which are not cacheable on disk", func(t *testing.T) {
+		memFs := afero.NewMemMapFs()
+		disk := &config.DiskCache{Fs: memFs, Dir: "/cache", TTL: time.Hour}
+		provider := relativeProvider{countingProvider: &countingProvider{}}
+		providers := config.Providers{{Name: "file", Provider: provider}}.RelativeTo("/app/x")
+
+		_, _ = providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, map[string]interface{}{"file": ".env"})
+		_, _ = providers.WithCache(config.NewCache(2, disk)).Fetch(stevedoreCtx, map[string]interface{}{"file": ".env"})
+
+		exists, _ := afero.DirExists(memFs, "/cache")
+		assert.False(t, exists)
+		assert.Equal(t, 2, provider.calls)
+	})
+}
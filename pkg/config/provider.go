@@ -2,9 +2,11 @@ package config
 
 import (
 	"fmt"
+	"sort"
 )
 
-// Provider is a provider for the configs and mounts section
+// Provider is a provider for the configs and mounts section.
+// Fetch is called concurrently while prefetching, so it has to be safe for concurrent use
 type Provider interface {
 	Fetch(
 		context map[string]string,
@@ -12,50 +14,183 @@ type Provider interface {
 	) (map[string]interface{}, error)
 }
 
+// SensitiveProvider is implemented by the providers whose fetched values are sensitive, such as secrets
+type SensitiveProvider interface {
+	Sensitive() bool
+}
+
+// SensitiveKeysProvider is implemented by the sensitive providers of which only some of the fetched keys are sensitive,
+// such as the values of the Secrets among the values of the ConfigMaps
+type SensitiveKeysProvider interface {
+	// FetchSensitiveKeys fetches the configs as Fetch does, along with the keys whose values are sensitive
+	FetchSensitiveKeys(context map[string]string, data interface{}) (map[string]interface{}, []string, error)
+}
+
+// CacheableProvider is implemented by the providers whose configs can be cached on disk across runs, such as the ones
+// fetched from a remote store. The configs of the other providers, such as the local files, are fetched in each run
+type CacheableProvider interface {
+	Cacheable() bool
+}
+
+// ManifestRelativeProvider is implemented by the providers which resolve the configs relative to the manifest, such as files
+type ManifestRelativeProvider interface {
+	// RelativeTo returns the provider which resolves the configs relative to the directory of the manifest
+	RelativeTo(manifestDir string) Provider
+}
+
 // ProviderImpl is a ProviderImpl
 type ProviderImpl struct {
 	Name     string
 	Context  map[string]string
 	Provider Provider
+	cache    *Cache
+	// manifestDir is the directory of the manifest which the provider resolves the configs relative to, if any
+	manifestDir string
 }
 
 // Providers represents the list of ProviderImpl
 type Providers []ProviderImpl
 
+// RelativeTo returns a copy of the providers in which the ManifestRelativeProvider(s) resolve the configs
+// relative to the given directory of the manifest. The other providers are retained as is
+func (p Providers) RelativeTo(manifestDir string) Providers {
+	result := make(Providers, 0, len(p))
+	for _, each := range p {
+		if relativeProvider, ok := each.Provider.(ManifestRelativeProvider); ok {
+			each.Provider = relativeProvider.RelativeTo(manifestDir)
+			each.manifestDir = manifestDir
+		}
+		result = append(result, each)
+	}
+	return result
+}
+
+// WithCache returns a copy of the providers whose fetches are memoized using the cache
+func (p Providers) WithCache(cache *Cache) Providers {
+	result := make(Providers, 0, len(p))
+	for _, each := range p {
+		each.cache = cache
+		result = append(result, each)
+	}
+	return result
+}
+
+// FirstReport returns true unless the message is already reported in the run of the cache of the providers,
+// marking it as reported. Without a cache, every report of the message is the first one
+func (p Providers) FirstReport(message string) bool {
+	for _, each := range p {
+		if each.cache != nil {
+			return each.cache.firstReport(message)
+		}
+	}
+	return true
+}
+
+// IsSensitive returns true if the values fetched by the provider with the given name are sensitive
+func (p Providers) IsSensitive(name string) bool {
+	for _, each := range p {
+		if each.Name == name {
+			return each.sensitive()
+		}
+	}
+	return false
+}
+
+func (p Providers) byName() map[string]ProviderImpl {
+	pluginsMap := map[string]ProviderImpl{}
+	for _, each := range p {
+		pluginsMap[each.Name] = each
+	}
+	return pluginsMap
+}
+
+// sensitive returns true if any of the values fetched by the provider can be sensitive
+func (impl ProviderImpl) sensitive() bool {
+	if _, ok := impl.Provider.(SensitiveKeysProvider); ok {
+		return true
+	}
+	sensitiveProvider, ok := impl.Provider.(SensitiveProvider)
+	return ok && sensitiveProvider.Sensitive()
+}
+
+// cacheable returns true if the configs fetched by the provider can be cached on disk, which they never can if sensitive
+func (impl ProviderImpl) cacheable() bool {
+	cacheableProvider, ok := impl.Provider.(CacheableProvider)
+	return ok && cacheableProvider.Cacheable() && !impl.sensitive()
+}
+
+// context returns the stevedore context along with the context of the provider, which takes precedence
+func (impl ProviderImpl) context(stevedoreCtx map[string]string) map[string]string {
+	ctx := make(map[string]string, len(stevedoreCtx)+len(impl.Context))
+	for k, v := range stevedoreCtx {
+		ctx[k] = v
+	}
+	for k, v := range impl.Context {
+		ctx[k] = v
+	}
+	return ctx
+}
+
+// fetch fetches the configs along with their sensitive keys using the cache, if any
+func (impl ProviderImpl) fetch(ctx map[string]string, data interface{}) (map[string]interface{}, []string, error) {
+	if impl.cache != nil {
+		return impl.cache.fetch(impl, ctx, data)
+	}
+	return impl.fetchSensitiveKeys(ctx, data)
+}
+
+// fetchSensitiveKeys fetches the configs from the provider along with their sensitive keys, which are
+// all the keys of a SensitiveProvider, unless it is a SensitiveKeysProvider
+func (impl ProviderImpl) fetchSensitiveKeys(ctx map[string]string, data interface{}) (map[string]interface{}, []string, error) {
+	if sensitiveKeysProvider, ok := impl.Provider.(SensitiveKeysProvider); ok {
+		return sensitiveKeysProvider.FetchSensitiveKeys(ctx, data)
+	}
+
+	result, err := impl.Provider.Fetch(ctx, data)
+	if err != nil || !impl.sensitive() {
+		return result, nil, err
+	}
+	keys := make([]string, 0, len(result))
+	for key := range result {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return result, keys, nil
+}
+
 // Fetch can be used to fetch configs from an external plugin
 func (p Providers) Fetch(
 	stevedoreCtx map[string]string,
 	data map[string]interface{},
 ) (map[string]map[string]interface{}, error) {
-	results := make(map[string]map[string]interface{}, len(data))
-
-	pluginsMap := map[string]ProviderImpl{}
+	results, _, err := p.FetchWithSensitiveKeys(stevedoreCtx, data)
+	return results, err
+}
 
-	for _, each := range p {
-		pluginsMap[each.Name] = each
-	}
+// FetchWithSensitiveKeys fetches the configs as Fetch does, along with the keys of the configs of each provider
+// whose values are sensitive
+func (p Providers) FetchWithSensitiveKeys(
+	stevedoreCtx map[string]string,
+	data map[string]interface{},
+) (map[string]map[string]interface{}, map[string][]string, error) {
+	results := make(map[string]map[string]interface{}, len(data))
+	sensitiveKeys := map[string][]string{}
+	pluginsMap := p.byName()
 
 	for rootKey, configs := range data {
 		fetcher, ok := pluginsMap[rootKey]
 		if !ok {
-			return nil, fmt.Errorf("could not find a plugin binary for the config: %v", rootKey)
-		}
-
-		ctx := make(map[string]string)
-
-		for k, v := range stevedoreCtx {
-			ctx[k] = v
-		}
-
-		for k, v := range fetcher.Context {
-			ctx[k] = v
+			return nil, nil, fmt.Errorf("could not find a plugin binary for the config: %v", rootKey)
 		}
 
-		result, err := fetcher.Provider.Fetch(ctx, configs)
+		result, keys, err := fetcher.fetch(fetcher.context(stevedoreCtx), configs)
 		if err != nil {
-			return nil, fmt.Errorf("error in fetching from provider: %v", err)
+			return nil, nil, fmt.Errorf("error in fetching from provider: %v", err)
 		}
 		results[rootKey] = result
+		if len(keys) != 0 {
+			sensitiveKeys[rootKey] = keys
+		}
 	}
-	return results, nil
+	return results, sensitiveKeys, nil
 }
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/databus23/helm-diff/diff"
 	"github.com/databus23/helm-diff/manifest"
@@ -23,48 +24,79 @@ import (
 )
 
 // Client is an abstraction through which helm can be interacted with
+//
+// Upstall returns ReleaseDriftError when not in dry run, if the release has a new revision
+// since it was planned as plannedReleaseVersion. plannedReleaseVersion 0 skips the check
 type Client interface {
 	Upstall(ctx context.Context, releaseName, chartName, chartVersion string, plannedReleaseVersion int32, namespace, values string, dryRun bool, timeout int64, atomic bool) (UpstallResponse, error)
+	Uninstall(ctx context.Context, releaseName, namespace string, dryRun bool, timeout int64) (UpstallResponse, error)
+	ManagedReleases(ctx context.Context, namespace string) ([]string, error)
+	Rollback(ctx context.Context, releaseName, namespace string, revision int32, dryRun bool, timeout int64) (UpstallResponse, error)
+	History(ctx context.Context, releaseName, namespace string) (Revisions, error)
+}
+
+// KubeOptions represents the kubernetes cluster with which the helm client interacts
+type KubeOptions struct {
+	KubeConfig  string
+	KubeContext string
+	BearerToken string
+	APIServer   string
 }
 
 // DefaultClient is an implementation of helm.Client
 type DefaultClient struct {
+	options  KubeOptions
+	settings *cli.EnvSettings
 }
 
-func debug(format string, v ...interface{}) {
-	if settings.Debug {
+// NewClient returns DefaultClient which interacts with the cluster given by options
+func NewClient(options KubeOptions) *DefaultClient {
+	settings := cli.New()
+	settings.KubeConfig = options.KubeConfig
+	settings.KubeContext = options.KubeContext
+	settings.KubeToken = options.BearerToken
+	settings.KubeAPIServer = options.APIServer
+	return &DefaultClient{options: options, settings: settings}
+}
+
+func (c *DefaultClient) debug(format string, v ...interface{}) {
+	if c.settings.Debug {
 		format = fmt.Sprintf("[debug] %s\n", format)
 		_ = log.Output(2, fmt.Sprintf(format, v...))
 	}
 }
 
-var settings = cli.New()
-
 // Upstall can install a new release or upgrade if already present
 func (c *DefaultClient) Upstall(ctx context.Context, releaseName, chartName, chartVersion string, plannedReleaseVersion int32, namespace, values string, dryRun bool, timeout int64, atomic bool) (UpstallResponse, error) {
-	cfg := &action.Configuration{}
-	helmDriver := os.Getenv("HELM_DRIVER")
-	configFlags := genericclioptions.ConfigFlags{
-		Namespace:   &namespace,
-		Context:     &settings.KubeContext,
-		BearerToken: &settings.KubeToken,
-		APIServer:   &settings.KubeAPIServer,
-		KubeConfig:  &settings.KubeConfig,
-	}
-	if err := cfg.Init(&configFlags, namespace, helmDriver, debug); err != nil {
-		log.Fatal(err)
+	cfg, err := c.actionConfiguration(releaseName, namespace)
+	if err != nil {
+		return UpstallResponse{}, err
 	}
 	histClient := action.NewHistory(cfg)
 	histClient.Max = 1
-	if _, err := histClient.Run(releaseName); err == driver.ErrReleaseNotFound {
-		releaseValue, err := install(cfg, releaseName, namespace, chartName, chartVersion, values, dryRun, atomic)
+	history, err := histClient.Run(releaseName)
+	if err != nil && err != driver.ErrReleaseNotFound {
+		return UpstallResponse{}, InitError{ReleaseName: releaseName, Namespace: namespace, Err: err}
+	}
+	if !dryRun {
+		if err := CheckDrift(releaseName, plannedReleaseVersion, history); err != nil {
+			return UpstallResponse{}, err
+		}
+	}
+	if err == driver.ErrReleaseNotFound {
+		releaseValue, err := install(c.settings, cfg, releaseName, namespace, chartName, chartVersion, values, dryRun, atomic)
 		if err != nil {
-			return UpstallResponse{}, fmt.Errorf("error installing: %v", err)
+			return UpstallResponse{}, err
+		}
+		if !dryRun {
+			if err := markManaged(cfg, os.Getenv("HELM_DRIVER"), namespace, releaseName, releaseValue.Version); err != nil {
+				warning("unable to mark release %s as managed by stevedore: %v", releaseName, err)
+			}
 		}
 		existingSpecs := make(map[string]*manifest.MappingResult)
 		newSpecs := manifest.Parse(releaseValue.Manifest, namespace)
 		var buffer strings.Builder
-		hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, true, 5, &buffer)
+		hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, false, 5, &buffer)
 		return UpstallResponse{
 			ExistingSpecs:         existingSpecs,
 			NewSpecs:              newSpecs,
@@ -75,20 +107,25 @@ func (c *DefaultClient) Upstall(ctx context.Context, releaseName, chartName, cha
 		}, nil
 	}
 	client := action.NewGet(cfg)
-	newRelease, err := upgrade(cfg, releaseName, namespace, chartName, chartVersion, values, dryRun, atomic)
+	newRelease, err := upgrade(c.settings, cfg, releaseName, namespace, chartName, chartVersion, values, dryRun, atomic)
 
 	if err != nil {
-		return UpstallResponse{}, fmt.Errorf("error upgrading: %v", err)
+		return UpstallResponse{}, err
+	}
+	if !dryRun {
+		if err := markManaged(cfg, os.Getenv("HELM_DRIVER"), namespace, releaseName, newRelease.Version); err != nil {
+			warning("unable to mark release %s as managed by stevedore: %v", releaseName, err)
+		}
 	}
 
 	existingRelease, err := client.Run(releaseName)
 	if err != nil {
-		return UpstallResponse{}, fmt.Errorf("error getting current release: %v", err)
+		return UpstallResponse{}, ReleaseError{ReleaseName: releaseName, Action: "upgrading", Err: fmt.Errorf("unable to get the current release: %v", err)}
 	}
 	existingSpecs := manifest.Parse(existingRelease.Manifest, namespace)
 	newSpecs := manifest.Parse(newRelease.Manifest, namespace)
 	var buffer strings.Builder
-	hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, true, 5, &buffer)
+	hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, false, 5, &buffer)
 	return UpstallResponse{
 		ExistingSpecs:         existingSpecs,
 		NewSpecs:              newSpecs,
@@ -99,7 +136,174 @@ func (c *DefaultClient) Upstall(ctx context.Context, releaseName, chartName, cha
 	}, nil
 }
 
-func install(cfg *action.Configuration, releaseName, namespace, chartName, chartVersion, values string, dryRun, atomic bool) (*release.Release, error) {
+// Uninstall removes the release along with its resources, if present.
+// On dry run, it only returns the resources which would be removed
+func (c *DefaultClient) Uninstall(ctx context.Context, releaseName, namespace string, dryRun bool, timeout int64) (UpstallResponse, error) {
+	cfg, err := c.actionConfiguration(releaseName, namespace)
+	if err != nil {
+		return UpstallResponse{}, err
+	}
+	history, err := action.NewHistory(cfg).Run(releaseName)
+	if err != nil && err != driver.ErrReleaseNotFound {
+		return UpstallResponse{}, InitError{ReleaseName: releaseName, Namespace: namespace, Err: err}
+	}
+	existingRelease := latest(history)
+	if existingRelease == nil {
+		return UpstallResponse{}, nil
+	}
+
+	existingSpecs := manifest.Parse(existingRelease.Manifest, namespace)
+	newSpecs := make(map[string]*manifest.MappingResult)
+	var buffer strings.Builder
+	hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, false, 5, &buffer)
+	var chartVersion string
+	if existingRelease.Chart != nil && existingRelease.Chart.Metadata != nil {
+		chartVersion = existingRelease.Chart.Metadata.Version
+	}
+	response := UpstallResponse{
+		ExistingSpecs:         existingSpecs,
+		NewSpecs:              newSpecs,
+		HasDiff:               hasDiff,
+		Diff:                  buffer.String(),
+		ChartVersion:          chartVersion,
+		CurrentReleaseVersion: int32(existingRelease.Version),
+	}
+	if dryRun {
+		return response, nil
+	}
+
+	client := action.NewUninstall(cfg)
+	client.Timeout = time.Duration(timeout) * time.Second
+	if _, err := client.Run(releaseName); err != nil {
+		return UpstallResponse{}, fmt.Errorf("error uninstalling: %v", err)
+	}
+	return response, nil
+}
+
+// ManagedReleases returns the names of releases in the namespace, which are upstalled by stevedore
+func (c *DefaultClient) ManagedReleases(ctx context.Context, namespace string) ([]string, error) {
+	cfg, err := c.actionConfiguration("", namespace)
+	if err != nil {
+		return nil, err
+	}
+	client := action.NewList(cfg)
+	client.All = true
+	client.Selector = managedSelector
+	client.SetStateMask()
+
+	releases, err := client.Run()
+	if err != nil {
+		return nil, fmt.Errorf("error listing releases in namespace %s: %v", namespace, err)
+	}
+
+	var result []string
+	for _, release := range releases {
+		result = append(result, release.Name)
+	}
+	return result, nil
+}
+
+// Rollback rolls back the release to the given revision, or to its previous revision when revision is 0.
+// On dry run, it only returns the changes which would be made
+func (c *DefaultClient) Rollback(ctx context.Context, releaseName, namespace string, revision int32, dryRun bool, timeout int64) (UpstallResponse, error) {
+	cfg, err := c.actionConfiguration(releaseName, namespace)
+	if err != nil {
+		return UpstallResponse{}, err
+	}
+	history, err := action.NewHistory(cfg).Run(releaseName)
+	if err != nil {
+		return UpstallResponse{}, fmt.Errorf("error getting release history: %v", err)
+	}
+
+	currentRelease := latest(history)
+	if currentRelease == nil {
+		return UpstallResponse{}, fmt.Errorf("release %s has no revisions", releaseName)
+	}
+	if revision == 0 {
+		revision = int32(currentRelease.Version) - 1
+	}
+	var targetRelease *release.Release
+	for _, r := range history {
+		if int32(r.Version) == revision {
+			targetRelease = r
+		}
+	}
+	if targetRelease == nil {
+		return UpstallResponse{}, fmt.Errorf("revision %d of release %s not found", revision, releaseName)
+	}
+
+	existingSpecs := manifest.Parse(currentRelease.Manifest, namespace)
+	newSpecs := manifest.Parse(targetRelease.Manifest, namespace)
+	var buffer strings.Builder
+	hasDiff := diff.Manifests(existingSpecs, newSpecs, []string{}, false, 5, &buffer)
+	var chartVersion string
+	if targetRelease.Chart != nil && targetRelease.Chart.Metadata != nil {
+		chartVersion = targetRelease.Chart.Metadata.Version
+	}
+	response := UpstallResponse{
+		ExistingSpecs:         existingSpecs,
+		NewSpecs:              newSpecs,
+		HasDiff:               hasDiff,
+		Diff:                  buffer.String(),
+		ChartVersion:          chartVersion,
+		CurrentReleaseVersion: int32(currentRelease.Version) + 1,
+	}
+	if dryRun {
+		return response, nil
+	}
+
+	client := action.NewRollback(cfg)
+	client.Version = int(revision)
+	client.Timeout = time.Duration(timeout) * time.Second
+	if err := client.Run(releaseName); err != nil {
+		return UpstallResponse{}, fmt.Errorf("error rolling back: %v", err)
+	}
+	if err := markManaged(cfg, os.Getenv("HELM_DRIVER"), namespace, releaseName, currentRelease.Version+1); err != nil {
+		warning("unable to mark release %s as managed by stevedore: %v", releaseName, err)
+	}
+	return response, nil
+}
+
+// History returns the revisions of the release, oldest first
+func (c *DefaultClient) History(ctx context.Context, releaseName, namespace string) (Revisions, error) {
+	cfg, err := c.actionConfiguration(releaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	history, err := action.NewHistory(cfg).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting release history: %v", err)
+	}
+	return newRevisions(history), nil
+}
+
+func (c *DefaultClient) actionConfiguration(releaseName, namespace string) (*action.Configuration, error) {
+	cfg := &action.Configuration{}
+	helmDriver := os.Getenv("HELM_DRIVER")
+	if err := cfg.Init(c.configFlags(namespace), namespace, helmDriver, c.debug); err != nil {
+		return nil, InitError{ReleaseName: releaseName, Namespace: namespace, Err: err}
+	}
+	return cfg, nil
+}
+
+// configFlags returns the flags to connect to the cluster given by the client's options,
+// rather than the ones from the environment
+func (c *DefaultClient) configFlags(namespace string) *genericclioptions.ConfigFlags {
+	return c.options.ConfigFlags(namespace)
+}
+
+// ConfigFlags returns the flags to connect to the namespace of the cluster given by the options
+func (options KubeOptions) ConfigFlags(namespace string) *genericclioptions.ConfigFlags {
+	return &genericclioptions.ConfigFlags{
+		Namespace:   &namespace,
+		Context:     &options.KubeContext,
+		BearerToken: &options.BearerToken,
+		APIServer:   &options.APIServer,
+		KubeConfig:  &options.KubeConfig,
+	}
+}
+
+func install(settings *cli.EnvSettings, cfg *action.Configuration, releaseName, namespace, chartName, chartVersion, values string, dryRun, atomic bool) (*release.Release, error) {
 	client := action.NewInstall(cfg)
 	client.DryRun = dryRun
 	client.ReleaseName = releaseName
@@ -107,21 +311,20 @@ func install(cfg *action.Configuration, releaseName, namespace, chartName, chart
 	client.Atomic = atomic
 	client.ChartPathOptions.Version = chartVersion
 
-	settings := cli.New()
 	cp, err := client.ChartPathOptions.LocateChart(chartName, settings)
 	if err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	p := getter.All(settings)
 
 	chartRequested, err := loader.Load(cp)
 	if err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	if err := checkIfInstallable(chartRequested); err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	if chartRequested.Metadata.Deprecated {
@@ -145,26 +348,30 @@ func install(cfg *action.Configuration, releaseName, namespace, chartName, chart
 					Debug:            settings.Debug,
 				}
 				if err := man.Update(); err != nil {
-					return nil, err
+					return nil, DependencyCheckError{ReleaseName: releaseName, ChartName: chartName, Err: err}
 				}
 				// Reload the chart with the updated Chart.lock file.
 				if chartRequested, err = loader.Load(cp); err != nil {
-					return nil, errors.Wrap(err, "failed reloading chart after repo update")
+					return nil, DependencyCheckError{ReleaseName: releaseName, ChartName: chartName, Err: errors.Wrap(err, "failed reloading chart after repo update")}
 				}
 			} else {
-				return nil, err
+				return nil, DependencyCheckError{ReleaseName: releaseName, ChartName: chartName, Err: err}
 			}
 		}
 	}
 
 	valuesMap := map[string]interface{}{}
 	if err := yaml.Unmarshal([]byte(values), &valuesMap); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse %s", values)
+		return nil, ReleaseError{ReleaseName: releaseName, Action: "installing", Err: errors.Wrapf(err, "failed to parse %s", values)}
+	}
+	newRelease, err := client.Run(chartRequested, valuesMap)
+	if err != nil {
+		return nil, ReleaseError{ReleaseName: releaseName, Action: "installing", Err: err}
 	}
-	return client.Run(chartRequested, valuesMap)
+	return newRelease, nil
 }
 
-func upgrade(cfg *action.Configuration, releaseName, namespace, chartName, chartVersion, values string, dryRun, atomic bool) (*release.Release, error) {
+func upgrade(settings *cli.EnvSettings, cfg *action.Configuration, releaseName, namespace, chartName, chartVersion, values string, dryRun, atomic bool) (*release.Release, error) {
 	client := action.NewUpgrade(cfg)
 	client.DryRun = dryRun
 	client.Namespace = namespace
@@ -173,16 +380,16 @@ func upgrade(cfg *action.Configuration, releaseName, namespace, chartName, chart
 
 	cp, err := client.ChartPathOptions.LocateChart(chartName, settings)
 	if err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	chartRequested, err := loader.Load(cp)
 	if err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	if err := checkIfInstallable(chartRequested); err != nil {
-		return nil, err
+		return nil, ChartLocationError{ReleaseName: releaseName, ChartName: chartName, ChartVersion: chartVersion, Err: err}
 	}
 
 	if chartRequested.Metadata.Deprecated {
@@ -194,15 +401,19 @@ func upgrade(cfg *action.Configuration, releaseName, namespace, chartName, chart
 		// As of Helm 2.4.0, this is treated as a stopping condition:
 		// https://github.com/helm/helm/issues/2209
 		if err := action.CheckDependencies(chartRequested, req); err != nil {
-			return nil, err
+			return nil, DependencyCheckError{ReleaseName: releaseName, ChartName: chartName, Err: err}
 		}
 	}
 
 	valuesMap := map[string]interface{}{}
 	if err := yaml.Unmarshal([]byte(values), &valuesMap); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse %s", values)
+		return nil, ReleaseError{ReleaseName: releaseName, Action: "upgrading", Err: errors.Wrapf(err, "failed to parse %s", values)}
+	}
+	newRelease, err := client.Run(releaseName, chartRequested, valuesMap)
+	if err != nil {
+		return nil, ReleaseError{ReleaseName: releaseName, Action: "upgrading", Err: err}
 	}
-	return client.Run(releaseName, chartRequested, valuesMap)
+	return newRelease, nil
 }
 
 func warning(format string, v ...interface{}) {
@@ -0,0 +1,81 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+- name: production
+  cluster:
+    server: https://production.example.com
+users:
+- name: admin
+  user:
+    token: kubeconfig-token
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: admin
+- name: production
+  context:
+    cluster: production
+    user: admin
+current-context: staging
+`
+
+func TestNewClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stevedore-kubeconfig")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	kubeconfig := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(kubeconfig, []byte(kubeconfigTemplate), 0600))
+
+	t.Run("should connect to the cluster of the given kube context", func(t *testing.T) {
+		staging := NewClient(KubeOptions{KubeConfig: kubeconfig, KubeContext: "staging"})
+		production := NewClient(KubeOptions{KubeConfig: kubeconfig, KubeContext: "production"})
+
+		stagingConfig, err := staging.configFlags("default").ToRESTConfig()
+		require.NoError(t, err)
+		productionConfig, err := production.configFlags("default").ToRESTConfig()
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://staging.example.com", stagingConfig.Host)
+		assert.Equal(t, "https://production.example.com", productionConfig.Host)
+	})
+
+	t.Run("should override the kubeconfig with bearer token and api server", func(t *testing.T) {
+		client := NewClient(KubeOptions{
+			KubeConfig:  kubeconfig,
+			KubeContext: "production",
+			BearerToken: "token",
+			APIServer:   "https://localhost:6443",
+		})
+
+		config, err := client.configFlags("default").ToRESTConfig()
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://localhost:6443", config.Host)
+		assert.Equal(t, "token", config.BearerToken)
+	})
+
+	t.Run("should use the namespace given", func(t *testing.T) {
+		client := NewClient(KubeOptions{KubeConfig: kubeconfig})
+
+		namespace, _, err := client.configFlags("services").ToRawKubeConfigLoader().Namespace()
+		require.NoError(t, err)
+
+		assert.Equal(t, "services", namespace)
+	})
+}
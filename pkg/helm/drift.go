@@ -0,0 +1,56 @@
+package helm
+
+import (
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// ReleaseDriftError represents a release whose live revision
+// has changed since the revision it was planned against
+type ReleaseDriftError struct {
+	ReleaseName           string
+	PlannedReleaseVersion int32
+	LiveReleaseVersion    int32
+}
+
+// Error returns the revision the release was planned against and its live revision
+func (err ReleaseDriftError) Error() string {
+	return fmt.Sprintf("release %s has drifted since it was planned: planned against revision %d, but live revision is %d",
+		err.ReleaseName, err.PlannedReleaseVersion-1, err.LiveReleaseVersion)
+}
+
+// Kind returns DriftFailure
+func (err ReleaseDriftError) Kind() FailureKind {
+	return DriftFailure
+}
+
+// CheckDrift returns ReleaseDriftError if the planned revision of the release
+// is not the one which would be created on top of its latest revision in history.
+//
+// plannedReleaseVersion 0 means the release was not planned, hence it is not checked
+func CheckDrift(releaseName string, plannedReleaseVersion int32, history []*release.Release) error {
+	if plannedReleaseVersion == 0 {
+		return nil
+	}
+
+	var liveReleaseVersion int32
+	if liveRelease := latest(history); liveRelease != nil {
+		liveReleaseVersion = int32(liveRelease.Version)
+	}
+
+	if liveReleaseVersion+1 != plannedReleaseVersion {
+		return ReleaseDriftError{ReleaseName: releaseName, PlannedReleaseVersion: plannedReleaseVersion, LiveReleaseVersion: liveReleaseVersion}
+	}
+	return nil
+}
+
+func latest(history []*release.Release) *release.Release {
+	var result *release.Release
+	for _, revision := range history {
+		if result == nil || revision.Version > result.Version {
+			result = revision
+		}
+	}
+	return result
+}
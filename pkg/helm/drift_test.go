@@ -0,0 +1,39 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestCheckDrift(t *testing.T) {
+	history := []*release.Release{{Version: 2}, {Version: 4}, {Version: 3}}
+
+	t.Run("should not return error when release was not planned", func(t *testing.T) {
+		assert.NoError(t, helm.CheckDrift("x-stevedore", 0, history))
+	})
+
+	t.Run("should not return error when planned revision follows the live revision", func(t *testing.T) {
+		assert.NoError(t, helm.CheckDrift("x-stevedore", 5, history))
+	})
+
+	t.Run("should not return error when planned to install a new release", func(t *testing.T) {
+		assert.NoError(t, helm.CheckDrift("x-stevedore", 1, nil))
+	})
+
+	t.Run("should return error when live revision has changed since plan", func(t *testing.T) {
+		err := helm.CheckDrift("x-stevedore", 4, history)
+
+		expected := helm.ReleaseDriftError{ReleaseName: "x-stevedore", PlannedReleaseVersion: 4, LiveReleaseVersion: 4}
+		assert.Equal(t, expected, err)
+		assert.Equal(t, "release x-stevedore has drifted since it was planned: planned against revision 3, but live revision is 4", err.Error())
+	})
+
+	t.Run("should return error when release was deleted since plan", func(t *testing.T) {
+		err := helm.CheckDrift("x-stevedore", 5, nil)
+
+		assert.Equal(t, helm.ReleaseDriftError{ReleaseName: "x-stevedore", PlannedReleaseVersion: 5, LiveReleaseVersion: 0}, err)
+	})
+}
@@ -0,0 +1,118 @@
+package helm
+
+import (
+	"fmt"
+)
+
+// FailureKind represents the stage at which interacting with helm has failed
+type FailureKind int
+
+// Kinds of failure when interacting with helm
+const (
+	InitFailure FailureKind = iota + 1
+	ChartLocationFailure
+	DependencyCheckFailure
+	ReleaseFailure
+	DriftFailure
+)
+
+// String returns the name of the stage at which it has failed
+func (kind FailureKind) String() string {
+	switch kind {
+	case InitFailure:
+		return "init"
+	case ChartLocationFailure:
+		return "chart location"
+	case DependencyCheckFailure:
+		return "dependency check"
+	case ReleaseFailure:
+		return "release"
+	case DriftFailure:
+		return "drift"
+	default:
+		return "unknown"
+	}
+}
+
+// Failure is an error which denotes the kind of failure when interacting with helm
+type Failure interface {
+	error
+	Kind() FailureKind
+}
+
+// InitError represents the failure to initialize helm for a release,
+// such as when the kubernetes cluster is unreachable
+type InitError struct {
+	ReleaseName string
+	Namespace   string
+	Err         error
+}
+
+// Error returns the release along with the reason for failure
+func (err InitError) Error() string {
+	if err.ReleaseName == "" {
+		return fmt.Sprintf("unable to initialize helm for namespace %s: %v", err.Namespace, err.Err)
+	}
+	return fmt.Sprintf("unable to initialize helm for %s in namespace %s: %v", err.ReleaseName, err.Namespace, err.Err)
+}
+
+// Kind returns InitFailure
+func (err InitError) Kind() FailureKind {
+	return InitFailure
+}
+
+// ChartLocationError represents the failure to locate the chart of a release
+type ChartLocationError struct {
+	ReleaseName  string
+	ChartName    string
+	ChartVersion string
+	Err          error
+}
+
+// Error returns the chart along with the reason for failure
+func (err ChartLocationError) Error() string {
+	chart := err.ChartName
+	if err.ChartVersion != "" {
+		chart = fmt.Sprintf("%s (version %s)", err.ChartName, err.ChartVersion)
+	}
+	return fmt.Sprintf("unable to locate chart %s for %s: %v", chart, err.ReleaseName, err.Err)
+}
+
+// Kind returns ChartLocationFailure
+func (err ChartLocationError) Kind() FailureKind {
+	return ChartLocationFailure
+}
+
+// DependencyCheckError represents the chart of a release having unfulfilled dependencies
+type DependencyCheckError struct {
+	ReleaseName string
+	ChartName   string
+	Err         error
+}
+
+// Error returns the chart along with the reason for failure
+func (err DependencyCheckError) Error() string {
+	return fmt.Sprintf("dependencies of chart %s for %s are not fulfilled: %v", err.ChartName, err.ReleaseName, err.Err)
+}
+
+// Kind returns DependencyCheckFailure
+func (err DependencyCheckError) Kind() FailureKind {
+	return DependencyCheckFailure
+}
+
+// ReleaseError represents the failure to install or upgrade a release
+type ReleaseError struct {
+	ReleaseName string
+	Action      string
+	Err         error
+}
+
+// Error returns the release along with the reason for failure
+func (err ReleaseError) Error() string {
+	return fmt.Sprintf("error when %s %s due to %v", err.Action, err.ReleaseName, err.Err)
+}
+
+// Kind returns ReleaseFailure
+func (err ReleaseError) Kind() FailureKind {
+	return ReleaseFailure
+}
@@ -0,0 +1,66 @@
+package helm_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailures(t *testing.T) {
+	cause := fmt.Errorf("some error")
+
+	testCases := []struct {
+		name    string
+		failure helm.Failure
+		kind    helm.FailureKind
+		message string
+	}{
+		{
+			name:    "init error",
+			failure: helm.InitError{ReleaseName: "redis", Namespace: "default", Err: cause},
+			kind:    helm.InitFailure,
+			message: "unable to initialize helm for redis in namespace default: some error",
+		},
+		{
+			name:    "init error without release",
+			failure: helm.InitError{Namespace: "default", Err: cause},
+			kind:    helm.InitFailure,
+			message: "unable to initialize helm for namespace default: some error",
+		},
+		{
+			name:    "chart location error",
+			failure: helm.ChartLocationError{ReleaseName: "redis", ChartName: "stable/redis", ChartVersion: "10.0.0", Err: cause},
+			kind:    helm.ChartLocationFailure,
+			message: "unable to locate chart stable/redis (version 10.0.0) for redis: some error",
+		},
+		{
+			name:    "dependency check error",
+			failure: helm.DependencyCheckError{ReleaseName: "redis", ChartName: "stable/redis", Err: cause},
+			kind:    helm.DependencyCheckFailure,
+			message: "dependencies of chart stable/redis for redis are not fulfilled: some error",
+		},
+		{
+			name:    "release error",
+			failure: helm.ReleaseError{ReleaseName: "redis", Action: "upgrading", Err: cause},
+			kind:    helm.ReleaseFailure,
+			message: "error when upgrading redis due to some error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.kind, testCase.failure.Kind())
+			assert.Equal(t, testCase.message, testCase.failure.Error())
+		})
+	}
+}
+
+func TestFailureKind_String(t *testing.T) {
+	assert.Equal(t, "init", helm.InitFailure.String())
+	assert.Equal(t, "chart location", helm.ChartLocationFailure.String())
+	assert.Equal(t, "dependency check", helm.DependencyCheckFailure.String())
+	assert.Equal(t, "release", helm.ReleaseFailure.String())
+	assert.Equal(t, "unknown", helm.FailureKind(0).String())
+}
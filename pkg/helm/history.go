@@ -0,0 +1,42 @@
+package helm
+
+import (
+	"sort"
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Revision represents a single revision in the history of a helm release
+type Revision struct {
+	Version     int32     `json:"revision" yaml:"revision"`
+	Updated     time.Time `json:"updated" yaml:"updated"`
+	Status      string    `json:"status" yaml:"status"`
+	Chart       string    `json:"chart" yaml:"chart"`
+	AppVersion  string    `json:"appVersion" yaml:"appVersion"`
+	Description string    `json:"description" yaml:"description"`
+}
+
+// Revisions is a collection of Revision
+type Revisions []Revision
+
+func newRevisions(history []*release.Release) Revisions {
+	revisions := Revisions{}
+	for _, revision := range history {
+		result := Revision{Version: int32(revision.Version)}
+		if revision.Info != nil {
+			result.Updated = revision.Info.LastDeployed.Time
+			result.Status = revision.Info.Status.String()
+			result.Description = revision.Info.Description
+		}
+		if revision.Chart != nil && revision.Chart.Metadata != nil {
+			result.Chart = revision.Chart.Metadata.Name + "-" + revision.Chart.Metadata.Version
+			result.AppVersion = revision.Chart.Metadata.AppVersion
+		}
+		revisions = append(revisions, result)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Version < revisions[j].Version
+	})
+	return revisions
+}
@@ -0,0 +1,36 @@
+package helm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	helmTime "helm.sh/helm/v3/pkg/time"
+)
+
+func TestNewRevisions(t *testing.T) {
+	t.Run("should return revisions sorted by version", func(t *testing.T) {
+		updated := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+		history := []*release.Release{
+			{
+				Version: 2,
+				Info:    &release.Info{LastDeployed: helmTime.Time{Time: updated}, Status: release.StatusDeployed, Description: "Upgrade complete"},
+				Chart:   &chart.Chart{Metadata: &chart.Metadata{Name: "redis", Version: "1.1.0", AppVersion: "6.0"}},
+			},
+			{
+				Version: 1,
+				Info:    &release.Info{Status: release.StatusSuperseded},
+			},
+		}
+
+		revisions := newRevisions(history)
+
+		expected := Revisions{
+			{Version: 1, Status: "superseded"},
+			{Version: 2, Updated: updated, Status: "deployed", Chart: "redis-1.1.0", AppVersion: "6.0", Description: "Upgrade complete"},
+		}
+		assert.Equal(t, expected, revisions)
+	})
+}
@@ -0,0 +1,40 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ManagedByLabel is the label stevedore adds to the helm storage object of every revision it upstalls
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByStevedore is the value of ManagedByLabel for releases managed by stevedore
+	ManagedByStevedore = "stevedore"
+)
+
+var managedSelector = fmt.Sprintf("%s=%s", ManagedByLabel, ManagedByStevedore)
+
+// markManaged labels the helm storage object of the given revision as managed by stevedore.
+// Only the secret and configmap helm drivers support labels
+func markManaged(cfg *action.Configuration, helmDriver, namespace, releaseName string, version int) error {
+	clientSet, err := cfg.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("sh.helm.release.v1.%s.v%d", releaseName, version)
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, ManagedByLabel, ManagedByStevedore))
+	switch helmDriver {
+	case "", "secret", "secrets":
+		_, err = clientSet.CoreV1().Secrets(namespace).Patch(context.TODO(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "configmap", "configmaps":
+		_, err = clientSet.CoreV1().ConfigMaps(namespace).Patch(context.TODO(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("helm driver %s does not support labels", helmDriver)
+	}
+	return err
+}
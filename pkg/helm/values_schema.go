@@ -0,0 +1,219 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/xeipuuv/gojsonschema"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+// ValuesIssue represents a problem with a key of the values
+type ValuesIssue struct {
+	// Path of the key, such as a.b[0].c
+	Path    string
+	Message string
+	// Value at the path, when it is known
+	Value interface{}
+	// Unknown is true when the chart has no schema and the key is not present in its default values
+	Unknown bool
+}
+
+// ChartNotFoundError represents the error when the chart is not available locally
+type ChartNotFoundError struct {
+	ChartName    string
+	ChartVersion string
+}
+
+// Error returns the underlying error
+func (err ChartNotFoundError) Error() string {
+	if err.ChartVersion == "" {
+		return fmt.Sprintf("chart %s is not available locally", err.ChartName)
+	}
+	return fmt.Sprintf("chart %s with version %s is not available locally", err.ChartName, err.ChartVersion)
+}
+
+// ChartLocator locates the charts without reaching the chart repositories
+type ChartLocator interface {
+	Locate(chartName, chartVersion string) (*chart.Chart, error)
+}
+
+// LocalChartLocator locates the charts from the local path or the helm repository cache
+type LocalChartLocator struct {
+	RepositoryCache string
+}
+
+// NewLocalChartLocator returns the locator which uses the helm repository cache
+func NewLocalChartLocator() LocalChartLocator {
+	return LocalChartLocator{RepositoryCache: cli.New().RepositoryCache}
+}
+
+// Locate loads the chart from the local path, or the archive of the chart with the given version
+// (latest if version is empty) from the helm repository cache
+func (locator LocalChartLocator) Locate(chartName, chartVersion string) (*chart.Chart, error) {
+	if _, err := os.Stat(chartName); err == nil {
+		return loader.Load(chartName)
+	}
+
+	name := chartName[strings.LastIndex(chartName, "/")+1:]
+	if chartVersion != "" {
+		archive := filepath.Join(locator.RepositoryCache, fmt.Sprintf("%s-%s.tgz", name, chartVersion))
+		if _, err := os.Stat(archive); err != nil {
+			return nil, ChartNotFoundError{ChartName: chartName, ChartVersion: chartVersion}
+		}
+		return loader.Load(archive)
+	}
+
+	archives, err := filepath.Glob(filepath.Join(locator.RepositoryCache, fmt.Sprintf("%s-*.tgz", name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *semver.Version
+	var latestArchive string
+	for _, archive := range archives {
+		version, err := semver.NewVersion(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(archive), name+"-"), ".tgz"))
+		if err != nil {
+			continue
+		}
+		if latest == nil || latest.LessThan(version) {
+			latest = version
+			latestArchive = archive
+		}
+	}
+	if latest == nil {
+		return nil, ChartNotFoundError{ChartName: chartName}
+	}
+	return loader.Load(latestArchive)
+}
+
+// ValidateValues validates the values against the values.schema.json of the chart.
+// If the chart has no schema, the keys of the values which are not present in the default values of the chart are reported as unknown
+func ValidateValues(chrt *chart.Chart, values map[string]interface{}) ([]ValuesIssue, error) {
+	if len(chrt.Schema) == 0 {
+		return unknownKeys(chrt, values), nil
+	}
+
+	document := jsonCompatible(values)
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(chrt.Schema), gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate values against the schema of chart %s: %v", chrt.Name(), err)
+	}
+
+	var issues []ValuesIssue
+	for _, resultError := range result.Errors() {
+		path := resultError.Field()
+		if property, ok := resultError.Details()["property"]; ok && resultError.Type() == "additional_property_not_allowed" {
+			path = fmt.Sprintf("%s.%v", path, property)
+		}
+		path = strings.TrimPrefix(path, gojsonschema.STRING_ROOT_SCHEMA_PROPERTY+".")
+		issues = append(issues, ValuesIssue{Path: indexed(document, path), Message: resultError.Description(), Value: resultError.Value()})
+	}
+	return issues, nil
+}
+
+func unknownKeys(chrt *chart.Chart, values map[string]interface{}) []ValuesIssue {
+	known := map[string]interface{}{"global": map[string]interface{}{}}
+	for key, value := range chrt.Values {
+		known[key] = value
+	}
+	for _, dependency := range chrt.Metadata.Dependencies {
+		name := dependency.Name
+		if dependency.Alias != "" {
+			name = dependency.Alias
+		}
+		known[name] = map[string]interface{}{}
+	}
+
+	var issues []ValuesIssue
+	collectUnknownKeys(values, known, "", &issues)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+	return issues
+}
+
+func collectUnknownKeys(values, defaults map[string]interface{}, path string, issues *[]ValuesIssue) {
+	for key, value := range values {
+		keyPath := key
+		if path != "" {
+			keyPath = fmt.Sprintf("%s.%s", path, key)
+		}
+
+		defaultValue, ok := defaults[key]
+		if !ok {
+			*issues = append(*issues, ValuesIssue{Path: keyPath, Message: fmt.Sprintf("%s is not present in the default values of the chart", keyPath), Unknown: true})
+			continue
+		}
+
+		nestedDefaults, isDefaultMap := stringMap(defaultValue)
+		nestedValues, isMap := stringMap(value)
+		if isDefaultMap && isMap && len(nestedDefaults) != 0 {
+			collectUnknownKeys(nestedValues, nestedDefaults, keyPath, issues)
+		}
+	}
+}
+
+func stringMap(value interface{}) (map[string]interface{}, bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return value, true
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, each := range value {
+			result[fmt.Sprintf("%v", key)] = each
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// jsonCompatible converts the maps with keys of any type into maps with string keys
+func jsonCompatible(value interface{}) interface{} {
+	if values, ok := stringMap(value); ok {
+		result := make(map[string]interface{}, len(values))
+		for key, each := range values {
+			result[key] = jsonCompatible(each)
+		}
+		return result
+	}
+	if items, ok := value.([]interface{}); ok {
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			result = append(result, jsonCompatible(item))
+		}
+		return result
+	}
+	return value
+}
+
+// indexed converts the segments of the path which index an array of the validated document, such as a.0.b, into a[0].b.
+// Numeric keys of the maps, such as ports.8080, are retained
+func indexed(document interface{}, path string) string {
+	var result strings.Builder
+	current := document
+	for index, segment := range strings.Split(path, ".") {
+		if items, ok := current.([]interface{}); ok {
+			if position, err := strconv.Atoi(segment); err == nil && position >= 0 && position < len(items) {
+				result.WriteString(fmt.Sprintf("[%d]", position))
+				current = items[position]
+				continue
+			}
+		}
+
+		if index != 0 {
+			result.WriteString(".")
+		}
+		result.WriteString(segment)
+		values, _ := current.(map[string]interface{})
+		current = values[segment]
+	}
+	return result.String()
+}
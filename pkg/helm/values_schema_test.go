@@ -0,0 +1,118 @@
+package helm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const valuesSchema = `{
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer"},
+    "ports": {"type": "array", "items": {"type": "object", "properties": {"port": {"type": "integer"}}}},
+    "listeners": {"type": "object", "additionalProperties": {"type": "object", "properties": {"port": {"type": "integer"}}}},
+    "image": {"type": "object", "additionalProperties": false, "properties": {"tag": {"type": "string"}}}
+  }
+}`
+
+func TestValidateValues(t *testing.T) {
+	t.Run("should validate values against the schema of the chart", func(t *testing.T) {
+		chrt := &chart.Chart{Metadata: &chart.Metadata{Name: "x-service"}, Schema: []byte(valuesSchema)}
+		values := map[string]interface{}{
+			"replicas":  "two",
+			"ports":     []interface{}{map[interface{}]interface{}{"port": "http"}},
+			"listeners": map[interface{}]interface{}{8080: map[interface{}]interface{}{"port": "http"}},
+			"image":     map[interface{}]interface{}{"tag": "1.0", "tagg": "1.0"},
+		}
+
+		issues, err := helm.ValidateValues(chrt, values)
+
+		require.NoError(t, err)
+		paths := map[string]interface{}{}
+		for _, issue := range issues {
+			assert.False(t, issue.Unknown)
+			paths[issue.Path] = issue.Value
+		}
+		assert.Equal(t, map[string]interface{}{"replicas": "two", "ports[0].port": "http", "listeners.8080.port": "http", "image.tagg": "1.0"}, paths)
+	})
+
+	t.Run("should not return issues for valid values", func(t *testing.T) {
+		chrt := &chart.Chart{Metadata: &chart.Metadata{Name: "x-service"}, Schema: []byte(valuesSchema)}
+
+		issues, err := helm.ValidateValues(chrt, map[string]interface{}{"replicas": 2})
+
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("should report the keys not present in the default values when the chart has no schema", func(t *testing.T) {
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "x-service", Dependencies: []*chart.Dependency{{Name: "postgresql", Alias: "db"}}},
+			Values:   map[string]interface{}{"image": map[string]interface{}{"tag": "1.0"}, "env": map[string]interface{}{}},
+		}
+		values := map[string]interface{}{
+			"image":    map[string]interface{}{"tag": "2.0", "tagg": "2.0"},
+			"env":      map[string]interface{}{"NAME": "x"},
+			"db":       map[string]interface{}{"enabled": true},
+			"global":   map[string]interface{}{"domain": "example.com"},
+			"replicas": 2,
+		}
+		expected := []helm.ValuesIssue{
+			{Path: "image.tagg", Message: "image.tagg is not present in the default values of the chart", Unknown: true},
+			{Path: "replicas", Message: "replicas is not present in the default values of the chart", Unknown: true},
+		}
+
+		issues, err := helm.ValidateValues(chrt, values)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+	})
+}
+
+func TestLocalChartLocatorLocate(t *testing.T) {
+	cache, err := ioutil.TempDir("", "stevedore-charts")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(cache) }()
+
+	for _, version := range []string{"1.0.0", "1.2.0", "1.10.0"} {
+		chrt := &chart.Chart{Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "x-service", Version: version}}
+		_, err := chartutil.Save(chrt, cache)
+		require.NoError(t, err)
+	}
+	locator := helm.LocalChartLocator{RepositoryCache: cache}
+
+	t.Run("should locate the chart with the given version from the cache", func(t *testing.T) {
+		chrt, err := locator.Locate("stable/x-service", "1.2.0")
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.0", chrt.Metadata.Version)
+	})
+
+	t.Run("should locate the latest chart from the cache when version is not given", func(t *testing.T) {
+		chrt, err := locator.Locate("stable/x-service", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.10.0", chrt.Metadata.Version)
+	})
+
+	t.Run("should locate the chart from the local path", func(t *testing.T) {
+		chrt, err := locator.Locate(filepath.Join(cache, "x-service-1.0.0.tgz"), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", chrt.Metadata.Version)
+	})
+
+	t.Run("should fail when the chart is not available locally", func(t *testing.T) {
+		_, err := locator.Locate("stable/y-service", "1.0.0")
+
+		assert.Equal(t, helm.ChartNotFoundError{ChartName: "stable/y-service", ChartVersion: "1.0.0"}, err)
+	})
+}
@@ -35,6 +35,66 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// History mocks base method.
+func (m *MockClient) History(ctx context.Context, releaseName, namespace string) (helm.Revisions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, releaseName, namespace)
+	ret0, _ := ret[0].(helm.Revisions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockClientMockRecorder) History(ctx, releaseName, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockClient)(nil).History), ctx, releaseName, namespace)
+}
+
+// ManagedReleases mocks base method.
+func (m *MockClient) ManagedReleases(ctx context.Context, namespace string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManagedReleases", ctx, namespace)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ManagedReleases indicates an expected call of ManagedReleases.
+func (mr *MockClientMockRecorder) ManagedReleases(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedReleases", reflect.TypeOf((*MockClient)(nil).ManagedReleases), ctx, namespace)
+}
+
+// Rollback mocks base method.
+func (m *MockClient) Rollback(ctx context.Context, releaseName, namespace string, revision int32, dryRun bool, timeout int64) (helm.UpstallResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, releaseName, namespace, revision, dryRun, timeout)
+	ret0, _ := ret[0].(helm.UpstallResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockClientMockRecorder) Rollback(ctx, releaseName, namespace, revision, dryRun, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockClient)(nil).Rollback), ctx, releaseName, namespace, revision, dryRun, timeout)
+}
+
+// Uninstall mocks base method.
+func (m *MockClient) Uninstall(ctx context.Context, releaseName, namespace string, dryRun bool, timeout int64) (helm.UpstallResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uninstall", ctx, releaseName, namespace, dryRun, timeout)
+	ret0, _ := ret[0].(helm.UpstallResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Uninstall indicates an expected call of Uninstall.
+func (mr *MockClientMockRecorder) Uninstall(ctx, releaseName, namespace, dryRun, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninstall", reflect.TypeOf((*MockClient)(nil).Uninstall), ctx, releaseName, namespace, dryRun, timeout)
+}
+
 // Upstall mocks base method.
 func (m *MockClient) Upstall(ctx context.Context, releaseName, chartName, chartVersion string, plannedReleaseVersion int32, namespace, values string, dryRun bool, timeout int64, atomic bool) (helm.UpstallResponse, error) {
 	m.ctrl.T.Helper()
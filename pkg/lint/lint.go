@@ -38,16 +38,8 @@ func (errs Errors) Error() string {
 // Lint is used to lint overrides
 func Lint(overrides stevedore.Overrides) error {
 	errors := Errors{}
-	matchesMap := make(map[string]struct{}, len(overrides.Spec))
-	for _, overrideSpec := range overrides.Spec {
-		matches := overrideSpec.Matches
-		matchesStr := fmt.Sprintf("%y", matches)
-		_, matchesExistsAlready := matchesMap[matchesStr]
-		if matchesExistsAlready {
-			errors = append(errors, Error{Description: "duplicate matches", Matches: matches})
-			continue
-		}
-		matchesMap[matchesStr] = struct{}{}
+	for _, index := range duplicateMatches(overrides) {
+		errors = append(errors, Error{Description: "duplicate matches", Matches: overrides.Spec[index].Matches})
 	}
 
 	if len(errors) != 0 {
@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/gojek/stevedore/pkg/utils/string"
+)
+
+// Severity represents how severe an issue is
+type Severity string
+
+// Severities of the issues
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// EnvFile represents the env specifications along with its file name
+type EnvFile struct {
+	Name  string
+	Specs stevedore.EnvSpecifications
+}
+
+// IgnoreFile represents the ignores along with its file name
+type IgnoreFile struct {
+	Name    string
+	Ignores stevedore.Ignores
+}
+
+// Input represents the stevedore files to be linted.
+// Rules which need the contexts are skipped if no contexts are provided,
+// and the rules which need the charts are skipped if no chart locator is provided
+type Input struct {
+	Manifests stevedore.ManifestFiles
+	Overrides stevedore.Overrides
+	Envs      []EnvFile
+	Ignores   []IgnoreFile
+	Contexts  stevedore.Contexts
+	Charts    helm.ChartLocator
+}
+
+// Issue represents a problem found by a rule
+type Issue struct {
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	File     string   `json:"file,omitempty" yaml:"file,omitempty"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// String returns the issue as a single line
+func (issue Issue) String() string {
+	if issue.File == "" {
+		return fmt.Sprintf("%s [%s] %s", issue.Severity, issue.Rule, issue.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", issue.Severity, issue.Rule, issue.File, issue.Message)
+}
+
+// Issues represents collection of Issue
+type Issues []Issue
+
+// HasErrors returns true if any of the issues is an error
+func (issues Issues) HasErrors() bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule represents a single lint check with a stable ID
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	check       func(input Input) []finding
+}
+
+// finding represents a problem found by a rule, before the rule and its severity are attached
+type finding struct {
+	file    string
+	message string
+}
+
+// Rules represents collection of Rule
+type Rules []Rule
+
+// IDs returns the ids of the rules
+func (rules Rules) IDs() []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+// Find returns the rule with the given id
+func (rules Rules) Find(id string) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// UnknownRuleError represents the error when disabling rules which are not known
+type UnknownRuleError struct {
+	IDs []string
+}
+
+// Error returns the underlying error
+func (err UnknownRuleError) Error() string {
+	return fmt.Sprintf("unknown lint rule(s) %s. Known rules are %s", strings.Join(err.IDs, ", "), strings.Join(All().IDs(), ", "))
+}
+
+// Run runs all the rules except the disabled ones and returns the issues found
+func Run(input Input, disabled ...string) (Issues, error) {
+	rules := All()
+	var unknown []string
+	for _, id := range disabled {
+		if _, ok := rules.Find(id); !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) != 0 {
+		return nil, UnknownRuleError{IDs: unknown}
+	}
+
+	issues := Issues{}
+	for _, rule := range rules {
+		if stringutils.Contains(disabled, rule.ID) {
+			continue
+		}
+		findings := rule.check(input)
+		sort.SliceStable(findings, func(i, j int) bool {
+			return findings[i].file < findings[j].file
+		})
+		for _, finding := range findings {
+			issues = append(issues, Issue{Rule: rule.ID, Severity: rule.Severity, File: finding.file, Message: finding.message})
+		}
+	}
+	return issues, nil
+}
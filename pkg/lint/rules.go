@@ -0,0 +1,374 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gojek/stevedore/pkg/stevedore"
+)
+
+// IDs of the rules
+const (
+	DuplicateOverrideMatches = "duplicate-override-matches"
+	UnknownOverrideRelease   = "unknown-override-release"
+	UnknownIgnoredRelease    = "unknown-ignored-release"
+	UnusedEnvVariable        = "unused-env-variable"
+	UndefinedPlaceholder     = "undefined-placeholder"
+	UnmatchedDeployTo        = "unmatched-deploy-to"
+	MissingDependency        = "missing-dependency"
+	DuplicateReleaseName     = "duplicate-release-name"
+	ChartAndChartSpec        = "chart-and-chart-spec"
+	InvalidValues            = "invalid-values"
+	UnknownValuesKey         = "unknown-values-key"
+)
+
+// All returns all the known rules
+func All() Rules {
+	return Rules{
+		{ID: DuplicateOverrideMatches, Severity: SeverityWarning, Description: "overrides with the same matches", check: checkDuplicateOverrideMatches},
+		{ID: UnknownOverrideRelease, Severity: SeverityWarning, Description: "overrides matching an application name which no manifest declares", check: checkUnknownOverrideRelease},
+		{ID: UnknownIgnoredRelease, Severity: SeverityWarning, Description: "ignored releases which no manifest declares", check: checkUnknownIgnoredRelease},
+		{ID: UnusedEnvVariable, Severity: SeverityInfo, Description: "env variables which are not used by any release of a context", check: checkUnusedEnvVariable},
+		{ID: UndefinedPlaceholder, Severity: SeverityWarning, Description: "placeholders without a default whose variable is not defined in the envs of a context", check: checkUndefinedPlaceholder},
+		{ID: UnmatchedDeployTo, Severity: SeverityWarning, Description: "manifests whose deployTo matches none of the contexts", check: checkUnmatchedDeployTo},
+		{ID: MissingDependency, Severity: SeverityError, Description: "releases depending on releases which no manifest declares", check: checkMissingDependency},
+		{ID: DuplicateReleaseName, Severity: SeverityError, Description: "releases declared more than once", check: checkDuplicateReleaseName},
+		{ID: ChartAndChartSpec, Severity: SeverityError, Description: "releases with both chart and chartSpec", check: checkChartAndChartSpec},
+		{ID: InvalidValues, Severity: SeverityError, Description: "values of a context which do not conform to the values.schema.json of the locally available chart", check: checkInvalidValues},
+		{ID: UnknownValuesKey, Severity: SeverityWarning, Description: "keys of the values of a context which are not present in the default values of the locally available chart without a schema", check: checkUnknownValuesKey},
+	}
+}
+
+func checkDuplicateOverrideMatches(input Input) []finding {
+	var findings []finding
+	for _, index := range duplicateMatches(input.Overrides) {
+		spec := input.Overrides.Spec[index]
+		findings = append(findings, finding{file: spec.FileName, message: fmt.Sprintf("found duplicate matches %s", describe(spec.Matches))})
+	}
+	return findings
+}
+
+func checkUnknownOverrideRelease(input Input) []finding {
+	releaseNames := declaredReleaseNames(input.Manifests)
+	var findings []finding
+	for _, spec := range input.Overrides.Spec {
+		condition, ok := spec.Matches[stevedore.ConditionApplicationName]
+		if !ok {
+			continue
+		}
+		if !matchesAny(spec.Matches, releaseNames) {
+			message := fmt.Sprintf("override matches %s %v, which is not declared in any manifest", stevedore.ConditionApplicationName, condition)
+			findings = append(findings, finding{file: spec.FileName, message: message})
+		}
+	}
+	return findings
+}
+
+func checkUnknownIgnoredRelease(input Input) []finding {
+	releaseNames := declaredReleaseNames(input.Manifests)
+	var findings []finding
+	for _, ignoreFile := range input.Ignores {
+		for _, ignore := range ignoreFile.Ignores {
+			for _, release := range ignore.Releases {
+				if _, ok := releaseNames[release.Name]; !ok {
+					message := fmt.Sprintf("ignored release %s is not declared in any manifest", release.Name)
+					findings = append(findings, finding{file: ignoreFile.Name, message: message})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnusedEnvVariable(input Input) []finding {
+	var findings []finding
+	for _, context := range input.Contexts {
+		used := map[string]struct{}{}
+		for _, spec := range applicableReleases(input, context) {
+			variables, _ := spec.Release.Values.Variables()
+			// placeholders in the configs, such as the paths of the file provider, use the variables as well
+			configVariables, _ := stevedore.Values(spec.Configs).Variables()
+			for _, variable := range append(variables, configVariables...) {
+				used[variable] = struct{}{}
+			}
+		}
+
+		for _, envFile := range input.Envs {
+			for _, env := range envFile.Specs {
+				if !env.IsApplicableFor(context) {
+					continue
+				}
+				for _, name := range sortedNames(env.Values) {
+					if _, ok := used[name]; !ok {
+						message := fmt.Sprintf("variable %s is not used by any release in context %s", name, context.Name)
+						findings = append(findings, finding{file: envFile.Name, message: message})
+					}
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkUndefinedPlaceholder(input Input) []finding {
+	var findings []finding
+	for _, context := range input.Contexts {
+		envs := applicableEnvs(input, context)
+		for _, manifestFile := range applicableManifests(input, context) {
+			for _, spec := range manifestFile.Spec {
+				// variables of the releases with configs can be fetched from the config providers
+				if len(spec.Configs) != 0 {
+					continue
+				}
+				undefined, _ := spec.Release.Values.UndefinedVariables(envs)
+				for _, name := range unique(undefined) {
+					message := fmt.Sprintf("variable %s used by release %s is not defined in context %s", name, spec.Release.Name, context.Name)
+					findings = append(findings, finding{file: manifestFile.File, message: message})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnmatchedDeployTo(input Input) []finding {
+	if len(input.Contexts) == 0 {
+		return nil
+	}
+
+	var findings []finding
+	for _, manifestFile := range input.Manifests {
+		matched := false
+		for _, context := range input.Contexts {
+			if manifestFile.IsApplicableFor(context) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			findings = append(findings, finding{file: manifestFile.File, message: "deployTo does not match any of the contexts"})
+		}
+	}
+	return findings
+}
+
+func checkMissingDependency(input Input) []finding {
+	releaseNames := declaredReleaseNames(input.Manifests)
+	var findings []finding
+	for _, manifestFile := range input.Manifests {
+		for _, spec := range manifestFile.Spec {
+			for _, dependency := range spec.DependsOn {
+				if _, ok := releaseNames[dependency]; !ok {
+					message := fmt.Sprintf("release %s depends on %s, which is not declared in any manifest", spec.Release.Name, dependency)
+					findings = append(findings, finding{file: manifestFile.File, message: message})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func checkDuplicateReleaseName(input Input) []finding {
+	if len(input.Contexts) == 0 {
+		return nil
+	}
+
+	var findings []finding
+	reported := map[string]bool{}
+	for _, context := range input.Contexts {
+		files := map[string][]string{}
+		var names []string
+		for _, manifestFile := range applicableManifests(input, context) {
+			for _, spec := range manifestFile.Spec {
+				name := spec.Release.Name
+				if _, ok := files[name]; !ok {
+					names = append(names, name)
+				}
+				files[name] = append(files[name], manifestFile.File)
+			}
+		}
+
+		for _, name := range names {
+			if len(files[name]) < 2 {
+				continue
+			}
+			declared := fmt.Sprintf("release %s is declared %d times in %s", name, len(files[name]), strings.Join(files[name], ", "))
+			// the same declarations are reported once, though they apply to many contexts
+			if reported[declared] {
+				continue
+			}
+			reported[declared] = true
+			message := fmt.Sprintf("%s in context %s", declared, context.Name)
+			findings = append(findings, finding{file: files[name][0], message: message})
+		}
+	}
+	return findings
+}
+
+func checkChartAndChartSpec(input Input) []finding {
+	var findings []finding
+	for _, manifestFile := range input.Manifests {
+		for _, spec := range manifestFile.Spec {
+			chartSpec := spec.Release.ChartSpec
+			if spec.Release.Chart != "" && (chartSpec.Name != "" || len(chartSpec.Dependencies) != 0) {
+				message := fmt.Sprintf("release %s has both chart and chartSpec", spec.Release.Name)
+				findings = append(findings, finding{file: manifestFile.File, message: message})
+			}
+		}
+	}
+	return findings
+}
+
+func checkInvalidValues(input Input) []finding {
+	return checkValues(input, false)
+}
+
+func checkUnknownValuesKey(input Input) []finding {
+	return checkValues(input, true)
+}
+
+// checkValues validates the values of the releases of each context against their charts.
+// Releases whose chart is not available locally are skipped,
+// and so are the invalid values which still have placeholders since they are substituted only while rendering
+func checkValues(input Input, unknown bool) []finding {
+	if input.Charts == nil {
+		return nil
+	}
+
+	var findings []finding
+	for _, context := range input.Contexts {
+		for _, manifestFile := range applicableManifests(input, context).TrackProvenance() {
+			specs, err := manifestFile.Spec.EnrichWith(context, input.Overrides)
+			if err != nil {
+				continue
+			}
+			for _, spec := range specs {
+				valuesErrors, _ := spec.ValidateValues(input.Charts)
+				for _, valuesError := range valuesErrors {
+					if valuesError.Unknown != unknown || hasPlaceholder(valuesError.Value) {
+						continue
+					}
+					file := manifestFile.File
+					if valuesError.Source != nil && valuesError.Source.File != "" {
+						file = valuesError.Source.File
+					}
+					message := fmt.Sprintf("%s of release %s in context %s: %s", valuesError.Path, spec.Release.Name, context.Name, valuesError.Reason)
+					findings = append(findings, finding{file: file, message: message})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// duplicateMatches returns the index of the overrides whose matches are already used by a previous override
+func duplicateMatches(overrides stevedore.Overrides) []int {
+	var result []int
+	matchesMap := make(map[string]struct{}, len(overrides.Spec))
+	for index, overrideSpec := range overrides.Spec {
+		matchesStr := fmt.Sprintf("%y", overrideSpec.Matches)
+		if _, matchesExistsAlready := matchesMap[matchesStr]; matchesExistsAlready {
+			result = append(result, index)
+			continue
+		}
+		matchesMap[matchesStr] = struct{}{}
+	}
+	return result
+}
+
+func declaredReleaseNames(manifests stevedore.ManifestFiles) map[string]struct{} {
+	result := map[string]struct{}{}
+	for _, manifestFile := range manifests {
+		for _, spec := range manifestFile.Spec {
+			result[spec.Release.Name] = struct{}{}
+		}
+	}
+	return result
+}
+
+func matchesAny(conditions stevedore.Conditions, releaseNames map[string]struct{}) bool {
+	for name := range releaseNames {
+		if conditions.Matches(stevedore.ConditionApplicationName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func applicableManifests(input Input, context stevedore.Context) stevedore.ManifestFiles {
+	var ignores stevedore.Ignores
+	for _, ignoreFile := range input.Ignores {
+		ignores = append(ignores, ignoreFile.Ignores...)
+	}
+	manifests, _ := input.Manifests.Filter(ignores, context)
+	return manifests
+}
+
+func applicableReleases(input Input, context stevedore.Context) stevedore.ReleaseSpecifications {
+	var result stevedore.ReleaseSpecifications
+	for _, manifestFile := range applicableManifests(input, context) {
+		// overrides with invalid merge directives are reported while loading them
+		specs, err := manifestFile.Spec.EnrichWith(context, input.Overrides)
+		if err != nil {
+			continue
+		}
+		result = append(result, specs...)
+	}
+	return result
+}
+
+func applicableEnvs(input Input, context stevedore.Context) stevedore.Substitute {
+	var envs stevedore.EnvSpecifications
+	for _, envFile := range input.Envs {
+		for _, env := range envFile.Specs {
+			if env.IsApplicableFor(context) {
+				envs = append(envs, env)
+			}
+		}
+	}
+	envs.Sort(context.ConditionWeights())
+
+	result := stevedore.Substitute{}
+	for _, env := range envs {
+		for name, value := range env.Values {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func hasPlaceholder(value interface{}) bool {
+	text, ok := value.(string)
+	return ok && strings.Contains(text, "${")
+}
+
+func describe(conditions stevedore.Conditions) string {
+	var result []string
+	for criteria, condition := range conditions {
+		result = append(result, fmt.Sprintf("%s=%v", criteria, condition))
+	}
+	sort.Strings(result)
+	return strings.Join(result, " ")
+}
+
+func sortedNames(substitute stevedore.Substitute) []string {
+	names := make([]string, 0, len(substitute))
+	for name := range substitute {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func unique(items []string) []string {
+	var result []string
+	seen := map[string]struct{}{}
+	for _, item := range items {
+		if _, ok := seen[item]; !ok {
+			seen[item] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,220 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	"github.com/gojek/stevedore/pkg/lint"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+type charts map[string]*chart.Chart
+
+func (charts charts) Locate(chartName, chartVersion string) (*chart.Chart, error) {
+	if chrt, ok := charts[chartName]; ok {
+		return chrt, nil
+	}
+	return nil, helm.ChartNotFoundError{ChartName: chartName, ChartVersion: chartVersion}
+}
+
+func TestRun(t *testing.T) {
+	staging := stevedore.Context{Name: "staging", Environment: "staging", EnvironmentType: "staging", Type: "components"}
+	manifests := func(specs ...stevedore.ReleaseSpecification) stevedore.ManifestFiles {
+		return stevedore.ManifestFiles{
+			{
+				File: "/mock/x-stevedore.yaml",
+				Manifest: stevedore.Manifest{
+					DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "staging"}},
+					Spec:     specs,
+				},
+			},
+		}
+	}
+	release := func(name string, values stevedore.Values) stevedore.ReleaseSpecification {
+		return stevedore.ReleaseSpecification{Release: stevedore.Release{Name: name, Namespace: "default", Chart: "chart/" + name, Values: values}}
+	}
+
+	t.Run("should not find any issue", func(t *testing.T) {
+		input := lint.Input{
+			Manifests: manifests(release("x-service", stevedore.Values{"name": "${NAME}", "port": "${PORT:-8080}"})),
+			Overrides: stevedore.Overrides{Spec: stevedore.OverrideSpecifications{
+				{FileName: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionApplicationName: "x-*"}},
+			}},
+			Envs: []lint.EnvFile{
+				{Name: "/mock/env.yaml", Specs: stevedore.EnvSpecifications{{Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Values: stevedore.Substitute{"NAME": "x"}}}},
+			},
+			Ignores:  []lint.IgnoreFile{{Name: "/mock/.stevedoreignore", Ignores: stevedore.Ignores{{Releases: stevedore.IgnoredReleases{{Name: "x-service"}}}}}},
+			Contexts: stevedore.Contexts{staging},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, lint.Issues{}, issues)
+		assert.False(t, issues.HasErrors())
+	})
+
+	t.Run("should find issues in overrides and ignores", func(t *testing.T) {
+		input := lint.Input{
+			Manifests: manifests(release("x-service", stevedore.Values{})),
+			Overrides: stevedore.Overrides{Spec: stevedore.OverrideSpecifications{
+				{FileName: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionApplicationName: "y-service"}},
+				{FileName: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionApplicationName: "y-service"}},
+			}},
+			Ignores: []lint.IgnoreFile{{Name: "/mock/.stevedoreignore", Ignores: stevedore.Ignores{{Releases: stevedore.IgnoredReleases{{Name: "z-service"}}}}}},
+		}
+		expected := lint.Issues{
+			{Rule: lint.DuplicateOverrideMatches, Severity: lint.SeverityWarning, File: "/mock/overrides.yaml", Message: "found duplicate matches applicationName=y-service"},
+			{Rule: lint.UnknownOverrideRelease, Severity: lint.SeverityWarning, File: "/mock/overrides.yaml", Message: "override matches applicationName y-service, which is not declared in any manifest"},
+			{Rule: lint.UnknownOverrideRelease, Severity: lint.SeverityWarning, File: "/mock/overrides.yaml", Message: "override matches applicationName y-service, which is not declared in any manifest"},
+			{Rule: lint.UnknownIgnoredRelease, Severity: lint.SeverityWarning, File: "/mock/.stevedoreignore", Message: "ignored release z-service is not declared in any manifest"},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+	})
+
+	t.Run("should find unused env variables and undefined placeholders per context", func(t *testing.T) {
+		withConfigs := release("z-service", stevedore.Values{"name": "${STORE_NAME}"})
+		withConfigs.Configs = stevedore.Configs{"file": []interface{}{map[interface{}]interface{}{"path": "secrets/${SECRETS_DIR}/db.yaml"}}}
+		input := lint.Input{
+			Manifests: manifests(release("x-service", stevedore.Values{"name": "${NAME}", "size": "${SIZE:?size is required}"}), withConfigs),
+			Overrides: stevedore.Overrides{Spec: stevedore.OverrideSpecifications{
+				{FileName: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Values: stevedore.Values{"replicas": "${REPLICAS}"}},
+			}},
+			Envs: []lint.EnvFile{
+				{Name: "/mock/env.yaml", Specs: stevedore.EnvSpecifications{
+					{Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Values: stevedore.Substitute{"NAME": "x", "REPLICAS": 2, "SECRETS_DIR": "staging", "UNUSED": "y"}},
+					{Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "production"}, Values: stevedore.Substitute{"OTHER": "y"}},
+				}},
+			},
+			Contexts: stevedore.Contexts{staging},
+		}
+		expected := lint.Issues{
+			{Rule: lint.UnusedEnvVariable, Severity: lint.SeverityInfo, File: "/mock/env.yaml", Message: "variable UNUSED is not used by any release in context staging"},
+			{Rule: lint.UndefinedPlaceholder, Severity: lint.SeverityWarning, File: "/mock/x-stevedore.yaml", Message: "variable SIZE used by release x-service is not defined in context staging"},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+	})
+
+	t.Run("should find issues in manifests", func(t *testing.T) {
+		dependent := release("x-service", stevedore.Values{})
+		dependent.DependsOn = []string{"y-service"}
+		both := release("z-service", stevedore.Values{})
+		both.Release.ChartSpec = stevedore.ChartSpec{Name: "z-service", Dependencies: stevedore.Dependencies{{Name: "redis"}}}
+		input := lint.Input{
+			Manifests: append(manifests(dependent, both), stevedore.ManifestFile{
+				File: "/mock/y-stevedore.yaml",
+				Manifest: stevedore.Manifest{
+					DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "production"}},
+					Spec:     stevedore.ReleaseSpecifications{release("x-service", stevedore.Values{})},
+				},
+			}),
+			Contexts: stevedore.Contexts{staging},
+		}
+		expected := lint.Issues{
+			{Rule: lint.UnmatchedDeployTo, Severity: lint.SeverityWarning, File: "/mock/y-stevedore.yaml", Message: "deployTo does not match any of the contexts"},
+			{Rule: lint.MissingDependency, Severity: lint.SeverityError, File: "/mock/x-stevedore.yaml", Message: "release x-service depends on y-service, which is not declared in any manifest"},
+			{Rule: lint.ChartAndChartSpec, Severity: lint.SeverityError, File: "/mock/x-stevedore.yaml", Message: "release z-service has both chart and chartSpec"},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+		assert.True(t, issues.HasErrors())
+	})
+
+	t.Run("should find releases declared more than once per context", func(t *testing.T) {
+		production := stevedore.Context{Name: "production", Environment: "production", EnvironmentType: "production", Type: "components"}
+		manifest := func(file, context string, names ...string) stevedore.ManifestFile {
+			var specs stevedore.ReleaseSpecifications
+			for _, name := range names {
+				specs = append(specs, release(name, stevedore.Values{}))
+			}
+			return stevedore.ManifestFile{
+				File:     file,
+				Manifest: stevedore.Manifest{DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: context}}, Spec: specs},
+			}
+		}
+		input := lint.Input{
+			Manifests: stevedore.ManifestFiles{
+				manifest("/mock/x-stevedore.yaml", "staging", "x-service", "y-service"),
+				manifest("/mock/y-stevedore.yaml", "staging", "x-service"),
+				manifest("/mock/z-stevedore.yaml", "production", "y-service"),
+			},
+			Contexts: stevedore.Contexts{staging, production},
+		}
+		expected := lint.Issues{
+			{Rule: lint.DuplicateReleaseName, Severity: lint.SeverityError, File: "/mock/x-stevedore.yaml", Message: "release x-service is declared 2 times in /mock/x-stevedore.yaml, /mock/y-stevedore.yaml in context staging"},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+	})
+
+	t.Run("should find invalid values and unknown keys of the locally available charts", func(t *testing.T) {
+		input := lint.Input{
+			Manifests: manifests(
+				release("x-service", stevedore.Values{"replicas": "${REPLICAS}", "image": map[string]interface{}{"tag": "1.0"}}),
+				release("y-service", stevedore.Values{"name": "y", "env": map[string]interface{}{"NAME": "y"}}),
+				release("z-service", stevedore.Values{"name": "z"}),
+			),
+			Overrides: stevedore.Overrides{Spec: stevedore.OverrideSpecifications{
+				{FileName: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Values: stevedore.Values{"image": map[string]interface{}{"tag": 2}}},
+			}},
+			Envs: []lint.EnvFile{
+				{Name: "/mock/env.yaml", Specs: stevedore.EnvSpecifications{{Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Values: stevedore.Substitute{"REPLICAS": 2}}}},
+			},
+			Contexts: stevedore.Contexts{staging},
+			Charts: charts{
+				"chart/x-service": {
+					Metadata: &chart.Metadata{Name: "x-service"},
+					Schema:   []byte(`{"properties": {"replicas": {"type": "integer"}, "image": {"properties": {"tag": {"type": "string"}}}}}`),
+				},
+				"chart/y-service": {
+					Metadata: &chart.Metadata{Name: "y-service"},
+					Values:   map[string]interface{}{"env": map[string]interface{}{}},
+				},
+			},
+		}
+		expected := lint.Issues{
+			{Rule: lint.InvalidValues, Severity: lint.SeverityError, File: "/mock/overrides.yaml", Message: "image.tag of release x-service in context staging: Invalid type. Expected: string, given: integer"},
+			{Rule: lint.UnknownValuesKey, Severity: lint.SeverityWarning, File: "/mock/overrides.yaml", Message: "image of release y-service in context staging: image is not present in the default values of the chart"},
+			{Rule: lint.UnknownValuesKey, Severity: lint.SeverityWarning, File: "/mock/x-stevedore.yaml", Message: "name of release y-service in context staging: name is not present in the default values of the chart"},
+		}
+
+		issues, err := lint.Run(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, issues)
+	})
+
+	t.Run("should not run the disabled rules", func(t *testing.T) {
+		dependent := release("x-service", stevedore.Values{})
+		dependent.DependsOn = []string{"y-service"}
+
+		issues, err := lint.Run(lint.Input{Manifests: manifests(dependent)}, lint.MissingDependency)
+
+		assert.NoError(t, err)
+		assert.Equal(t, lint.Issues{}, issues)
+	})
+
+	t.Run("should fail to disable unknown rules", func(t *testing.T) {
+		_, err := lint.Run(lint.Input{}, lint.MissingDependency, "unknown-rule")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "unknown lint rule(s) unknown-rule. Known rules are duplicate-override-matches, ")
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package merger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Merge directives, which can be used in place of a value to control how it is merged into the base value
+//
+//	env:
+//	  $append:                # appends the items to the base list
+//	    - name: LOG_LEVEL
+//	      value: debug
+//	tolerations:
+//	  $prepend: [...]         # prepends the items to the base list
+//	resources:
+//	  $replace: {...}         # replaces the base value instead of merging
+//	extraVolumes:
+//	  $mergeBy: name          # merges the items into the base list item having the same name, or appends them
+//	  $items: [...]
+//	podAnnotations:
+//	  $delete: true           # deletes the key from the base values
+const (
+	AppendDirective  = "$append"
+	PrependDirective = "$prepend"
+	ReplaceDirective = "$replace"
+	MergeByDirective = "$mergeBy"
+	ItemsDirective   = "$items"
+	DeleteDirective  = "$delete"
+)
+
+var directiveKeys = []string{AppendDirective, PrependDirective, ReplaceDirective, MergeByDirective, ItemsDirective, DeleteDirective}
+
+// DirectiveError represents an invalid merge directive
+type DirectiveError struct {
+	Path   string
+	Reason string
+}
+
+// Error returns the formatted error message
+func (err DirectiveError) Error() string {
+	return fmt.Sprintf("invalid merge directive at %s: %s", err.Path, err.Reason)
+}
+
+type directive struct {
+	name  string
+	value interface{}
+	key   string
+}
+
+// parseDirective returns the directive if the value is a map having any of the directive keys
+func parseDirective(value interface{}, path string) (directive, bool, error) {
+	valueMap, ok := toStringMap(value)
+	if !ok {
+		return directive{}, false, nil
+	}
+
+	var names []string
+	for key := range valueMap {
+		for _, directiveKey := range directiveKeys {
+			if key == directiveKey {
+				names = append(names, key)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return directive{}, false, nil
+	}
+	if len(names) != len(valueMap) {
+		return directive{}, false, DirectiveError{path, "directives can not be mixed with values"}
+	}
+	sort.Strings(names)
+
+	switch strings.Join(names, ",") {
+	case AppendDirective, PrependDirective:
+		name := names[0]
+		if _, ok := toList(valueMap[name]); !ok {
+			return directive{}, false, DirectiveError{path, fmt.Sprintf("%s requires a list", name)}
+		}
+		return directive{name: name, value: valueMap[name]}, true, nil
+	case ReplaceDirective:
+		return directive{name: ReplaceDirective, value: valueMap[ReplaceDirective]}, true, nil
+	case DeleteDirective:
+		if valueMap[DeleteDirective] != true {
+			return directive{}, false, DirectiveError{path, fmt.Sprintf("%s requires true", DeleteDirective)}
+		}
+		return directive{name: DeleteDirective}, true, nil
+	case fmt.Sprintf("%s,%s", ItemsDirective, MergeByDirective):
+		key, ok := valueMap[MergeByDirective].(string)
+		if !ok || key == "" {
+			return directive{}, false, DirectiveError{path, fmt.Sprintf("%s requires the name of the key", MergeByDirective)}
+		}
+		items, ok := toList(valueMap[ItemsDirective])
+		if !ok {
+			return directive{}, false, DirectiveError{path, fmt.Sprintf("%s requires a list", ItemsDirective)}
+		}
+		for _, item := range items {
+			if _, ok := toStringMap(item); !ok {
+				return directive{}, false, DirectiveError{path, fmt.Sprintf("%s with %s requires a list of maps", ItemsDirective, MergeByDirective)}
+			}
+		}
+		return directive{name: MergeByDirective, value: items, key: key}, true, nil
+	default:
+		return directive{}, false, DirectiveError{path, fmt.Sprintf("unsupported combination of directives %s", strings.Join(names, ", "))}
+	}
+}
+
+// apply returns the result of applying the directive on the base value, and whether the key has to be deleted.
+// A base value which is not a list is considered as an empty list by the list directives
+func (d directive) apply(base interface{}, path string) (interface{}, bool, error) {
+	baseList, _ := toList(base)
+	switch d.name {
+	case DeleteDirective:
+		return nil, true, nil
+	case ReplaceDirective:
+		valueMap, ok := toStringMap(d.value)
+		if !ok {
+			return copyValue(d.value), false, nil
+		}
+		result, err := merge(nil, valueMap, path)
+		if err != nil {
+			return nil, false, err
+		}
+		return fromStringMap(result, d.value), false, nil
+	case AppendDirective:
+		items, _ := toList(d.value)
+		result := append(append([]interface{}{}, baseList...), items...)
+		return result, false, nil
+	case PrependDirective:
+		items, _ := toList(d.value)
+		result := append(append([]interface{}{}, items...), baseList...)
+		return result, false, nil
+	default:
+		return d.mergeBy(baseList, path)
+	}
+}
+
+func (d directive) mergeBy(baseList []interface{}, path string) (interface{}, bool, error) {
+	result := append([]interface{}{}, baseList...)
+	items, _ := toList(d.value)
+	for _, item := range items {
+		itemMap, _ := toStringMap(item)
+		index := d.indexOf(result, itemMap[d.key])
+		if index < 0 {
+			added, err := merge(nil, itemMap, fmt.Sprintf("%s[%d]", path, len(result)))
+			if err != nil {
+				return nil, false, err
+			}
+			result = append(result, fromStringMap(added, item))
+			continue
+		}
+		merged, err := merge(result[index], itemMap, fmt.Sprintf("%s[%d]", path, index))
+		if err != nil {
+			return nil, false, err
+		}
+		result[index] = fromStringMap(merged, result[index])
+	}
+	return result, false, nil
+}
+
+func (d directive) indexOf(list []interface{}, value interface{}) int {
+	if value == nil {
+		return -1
+	}
+	for index, item := range list {
+		itemMap, ok := toStringMap(item)
+		if ok && reflect.DeepEqual(itemMap[d.key], value) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Validate returns error if any of the merge directives in the values are invalid, or are used at the root of the values
+func Validate(values map[string]interface{}) error {
+	if err := validateRoot(values); err != nil {
+		return err
+	}
+	return validate(values, "")
+}
+
+func validate(value interface{}, path string) error {
+	if d, ok, err := parseDirective(value, path); err != nil || ok {
+		if err != nil || d.name == DeleteDirective {
+			return err
+		}
+		if d.name == MergeByDirective {
+			items, _ := toList(d.value)
+			for index, item := range items {
+				if err := validate(item, fmt.Sprintf("%s[%d]", path, index)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return validate(d.value, path)
+	}
+
+	valueMap, ok := toStringMap(value)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(valueMap))
+	for key := range valueMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validate(valueMap[key], join(path, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package merger_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gojek/stevedore/pkg/merger"
+)
+
+func TestMergeWithDirectives(t *testing.T) {
+	base := map[string]interface{}{
+		"env": []interface{}{
+			map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "info"},
+		},
+		"tolerations": []interface{}{"base"},
+		"resources":   map[interface{}]interface{}{"limits": map[interface{}]interface{}{"cpu": "100m"}},
+		"annotations": map[interface{}]interface{}{"team": "platform", "owner": "infra"},
+	}
+
+	t.Run("should append and prepend to the base list", func(t *testing.T) {
+		override := map[string]interface{}{
+			"env":         map[interface{}]interface{}{"$append": []interface{}{map[interface{}]interface{}{"name": "DEBUG", "value": true}}},
+			"tolerations": map[interface{}]interface{}{"$prepend": []interface{}{"first"}},
+			"volumes":     map[interface{}]interface{}{"$append": []interface{}{"new"}},
+		}
+
+		actual, err := merger.Merge(base, override)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "info"},
+			map[interface{}]interface{}{"name": "DEBUG", "value": true},
+		}, actual["env"])
+		assert.Equal(t, []interface{}{"first", "base"}, actual["tolerations"])
+		assert.Equal(t, []interface{}{"new"}, actual["volumes"])
+	})
+
+	t.Run("should replace the base value instead of merging", func(t *testing.T) {
+		override := map[string]interface{}{
+			"resources": map[interface{}]interface{}{"$replace": map[interface{}]interface{}{"requests": map[interface{}]interface{}{"cpu": "50m"}}},
+		}
+
+		actual, err := merger.Merge(base, override)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[interface{}]interface{}{"requests": map[interface{}]interface{}{"cpu": "50m"}}, actual["resources"])
+	})
+
+	t.Run("should merge list items by key", func(t *testing.T) {
+		override := map[string]interface{}{
+			"env": map[interface{}]interface{}{
+				"$mergeBy": "name",
+				"$items": []interface{}{
+					map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+					map[interface{}]interface{}{"name": "PORT", "value": 8080},
+				},
+			},
+		}
+
+		actual, err := merger.Merge(base, override)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+			map[interface{}]interface{}{"name": "PORT", "value": 8080},
+		}, actual["env"])
+	})
+
+	t.Run("should delete the key from base", func(t *testing.T) {
+		override := map[string]interface{}{
+			"annotations": map[interface{}]interface{}{"owner": map[interface{}]interface{}{"$delete": true}},
+			"resources":   map[interface{}]interface{}{"$delete": true},
+		}
+
+		actual, err := merger.Merge(base, override)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[interface{}]interface{}{"team": "platform"}, actual["annotations"])
+		assert.NotContains(t, actual, "resources")
+	})
+
+	t.Run("should not modify the base", func(t *testing.T) {
+		override := map[string]interface{}{
+			"annotations": map[interface{}]interface{}{"team": "core", "owner": map[interface{}]interface{}{"$delete": true}},
+		}
+
+		actual, err := merger.Merge(base, override)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[interface{}]interface{}{"team": "core"}, actual["annotations"])
+		assert.Equal(t, map[interface{}]interface{}{"team": "platform", "owner": "infra"}, base["annotations"])
+	})
+
+	t.Run("should return error for invalid directive", func(t *testing.T) {
+		override := map[string]interface{}{
+			"env": map[interface{}]interface{}{"$mergeBy": "name", "$items": []interface{}{"not a map"}},
+		}
+
+		_, err := merger.Merge(base, override)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at env: $items with $mergeBy requires a list of maps", err.Error())
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should return error for invalid nested directives", func(t *testing.T) {
+		values := map[string]interface{}{
+			"resources": map[interface{}]interface{}{
+				"$replace": map[interface{}]interface{}{"limits": map[interface{}]interface{}{"$delete": "yes"}},
+			},
+		}
+
+		err := merger.Validate(values)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at resources.limits: $delete requires true", err.Error())
+		}
+	})
+
+	t.Run("should return error when directives are mixed with values", func(t *testing.T) {
+		values := map[string]interface{}{
+			"env": map[interface{}]interface{}{"$append": []interface{}{}, "name": "value"},
+		}
+
+		err := merger.Validate(values)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at env: directives can not be mixed with values", err.Error())
+		}
+	})
+
+	t.Run("should return error for directives at root", func(t *testing.T) {
+		values := map[string]interface{}{"$replace": map[interface{}]interface{}{"name": "value"}}
+
+		err := merger.Validate(values)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at root: directives can not be used at root", err.Error())
+		}
+	})
+
+	t.Run("should not return error for valid directives", func(t *testing.T) {
+		values := map[string]interface{}{
+			"env": map[interface{}]interface{}{"$mergeBy": "name", "$items": []interface{}{map[interface{}]interface{}{"name": "PORT"}}},
+		}
+
+		assert.NoError(t, merger.Validate(values))
+	})
+}
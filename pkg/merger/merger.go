@@ -1,18 +1,207 @@
 package merger
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/imdario/mergo"
 )
 
-// Merge array of hashes
+// Merge array of hashes.
+// The merge directives (see AppendDirective) are applied first, and the rest of the values are merged using mergo,
+// such that maps are merged deeply, whereas other values, including lists, are overridden by the later hashes.
+// None of the hashes are modified
 func Merge(hashes ...map[string]interface{}) (map[string]interface{}, error) {
 
 	finalMap := make(map[string]interface{})
 
 	for _, hash := range hashes {
-		if err := mergo.Merge(&finalMap, hash, mergo.WithOverride); err != nil {
+		if err := validateRoot(hash); err != nil {
+			return nil, err
+		}
+		merged, err := merge(finalMap, hash, "")
+		if err != nil {
 			return nil, err
 		}
+		finalMap = merged
 	}
 	return finalMap, nil
 }
+
+// merge returns the result of merging src into dst, by applying the directives in src
+// and then merging the rest of src using mergo
+func merge(dst interface{}, src map[string]interface{}, path string) (map[string]interface{}, error) {
+	result, plain, err := applyDirectives(copyValue(dst), src, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergo.Merge(&result, copyValue(plain), mergo.WithOverride); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyDirectives returns dst with the directives in src applied, along with src without the directives
+func applyDirectives(dst interface{}, src map[string]interface{}, path string) (map[string]interface{}, map[string]interface{}, error) {
+	dstMap, _ := toStringMap(dst)
+	result := make(map[string]interface{}, len(dstMap))
+	for key, value := range dstMap {
+		result[key] = value
+	}
+
+	plain := make(map[string]interface{}, len(src))
+	for key, value := range src {
+		keyPath := join(path, key)
+		d, ok, err := parseDirective(value, keyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			applied, deleted, err := d.apply(result[key], keyPath)
+			if err != nil {
+				return nil, nil, err
+			}
+			if deleted {
+				delete(result, key)
+			} else {
+				result[key] = applied
+			}
+			continue
+		}
+
+		valueMap, ok := toStringMap(value)
+		if !ok || !containsDirective(value) {
+			plain[key] = value
+			continue
+		}
+		applied, nestedPlain, err := applyDirectives(result[key], valueMap, keyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		like := result[key]
+		if _, ok := toStringMap(like); !ok {
+			like = value
+		}
+		result[key] = fromStringMap(applied, like)
+		plain[key] = fromStringMap(nestedPlain, value)
+	}
+	return result, plain, nil
+}
+
+// containsDirective returns true if the value or any of the values nested in its maps is a directive
+func containsDirective(value interface{}) bool {
+	if _, ok, err := parseDirective(value, ""); ok || err != nil {
+		return true
+	}
+	valueMap, ok := toStringMap(value)
+	if !ok {
+		return false
+	}
+	for _, item := range valueMap {
+		if containsDirective(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRoot returns error if any of the keys at the root of the values is a directive,
+// as there is no key holding the values which the directive could apply on
+func validateRoot(values map[string]interface{}) error {
+	for key := range values {
+		for _, directiveKey := range directiveKeys {
+			if key == directiveKey {
+				return DirectiveError{Path: "root", Reason: "directives can not be used at root"}
+			}
+		}
+	}
+	return nil
+}
+
+// copyValue returns a deep copy of the maps and lists in the value, such that merging it using mergo,
+// which merges into the nested maps in place, does not modify the hashes given to Merge
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[key] = copyValue(item)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(value))
+		for key, item := range value {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			result = append(result, copyValue(item))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return value, true
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[fmt.Sprintf("%v", key)] = item
+		}
+		return result, true
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Map || reflected.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	result := make(map[string]interface{}, reflected.Len())
+	for _, key := range reflected.MapKeys() {
+		result[key.String()] = reflected.MapIndex(key).Interface()
+	}
+	return result, true
+}
+
+// fromStringMap returns the map as map[interface{}]interface{} if the given value is of that type,
+// such that the merged maps retain the type of the maps decoded from yaml
+func fromStringMap(value map[string]interface{}, like interface{}) interface{} {
+	if _, ok := like.(map[interface{}]interface{}); !ok {
+		return value
+	}
+	result := make(map[interface{}]interface{}, len(value))
+	for key, item := range value {
+		result[key] = item
+	}
+	return result
+}
+
+func toList(value interface{}) ([]interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	if list, ok := value.([]interface{}); ok {
+		return list, true
+	}
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return nil, false
+	}
+	result := make([]interface{}, 0, reflected.Len())
+	for i := 0; i < reflected.Len(); i++ {
+		result = append(result, reflected.Index(i).Interface())
+	}
+	return result, true
+}
@@ -1,9 +1,11 @@
 package merger_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/imdario/mergo"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gojek/stevedore/pkg/merger"
@@ -124,3 +126,59 @@ func TestMerge(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeRetainsMergoSemantics(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"a": 3,
+			"m": map[interface{}]interface{}{"x": 1},
+			"l": []interface{}{1},
+		}
+	}
+	overrides := []map[string]interface{}{
+		{"a": nil},
+		{"m": nil},
+		{"a": 0},
+		{"a": ""},
+		{"a": false},
+		{"m": map[interface{}]interface{}{"x": 0}},
+		{"a": map[interface{}]interface{}{}},
+		{"m": map[interface{}]interface{}{}},
+		{"l": []interface{}{}},
+		{"a": map[interface{}]interface{}{"x": 1}},
+		{"m": "value"},
+		{"m": map[interface{}]interface{}{"x": map[interface{}]interface{}{"y": 2}}},
+		{"l": map[interface{}]interface{}{"x": 1}},
+		{"m": []interface{}{1}},
+	}
+
+	for _, override := range overrides {
+		t.Run(fmt.Sprintf("should merge %v as mergo does", override), func(t *testing.T) {
+			expected := map[string]interface{}{}
+			assert.NoError(t, mergo.Merge(&expected, base(), mergo.WithOverride))
+			assert.NoError(t, mergo.Merge(&expected, override, mergo.WithOverride))
+
+			values := base()
+			actual, err := merger.Merge(values, override)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, base(), values)
+		})
+	}
+}
+
+func TestMergeWithRootDirectives(t *testing.T) {
+	base := map[string]interface{}{"key1": "valueA1"}
+
+	for _, override := range []map[string]interface{}{
+		{"$delete": true},
+		{"$replace": map[interface{}]interface{}{"key1": "valueB1"}},
+	} {
+		_, err := merger.Merge(base, override)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at root: directives can not be used at root", err.Error())
+		}
+	}
+}
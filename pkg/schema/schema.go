@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gojek/stevedore/pkg/stevedore"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema represents a JSON Schema
+type Schema map[string]interface{}
+
+// Document represents the JSON Schema of a stevedore yaml
+type Document struct {
+	// Name of the document, which is used to filter the documents
+	Name   string
+	Schema Schema
+}
+
+// FileName returns the name of the file to which the document is written
+func (document Document) FileName() string {
+	return fmt.Sprintf("stevedore-%s.schema.json", document.Name)
+}
+
+// JSON returns the schema as indented json
+func (document Document) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(document.Schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Documents represents collection of Document
+type Documents []Document
+
+// Names returns the names of the documents
+func (documents Documents) Names() []string {
+	names := make([]string, 0, len(documents))
+	for _, document := range documents {
+		names = append(names, document.Name)
+	}
+	return names
+}
+
+// Find returns the document with the given name
+func (documents Documents) Find(name string) (Document, bool) {
+	for _, document := range documents {
+		if document.Name == name {
+			return document, true
+		}
+	}
+	return Document{}, false
+}
+
+var (
+	conditionsType = reflect.TypeOf(stevedore.Conditions{})
+	valuesType     = reflect.TypeOf(stevedore.Values{})
+	substituteType = reflect.TypeOf(stevedore.Substitute{})
+	configsType    = reflect.TypeOf(stevedore.Configs{})
+)
+
+// All returns the JSON Schema of the manifest, override, env and ignore yaml(s)
+func All() Documents {
+	return Documents{
+		{Name: "manifest", Schema: kindSchema("Stevedore Manifest", stevedore.KindStevedoreManifest, stevedore.Manifest{})},
+		{Name: "override", Schema: kindSchema("Stevedore Override", stevedore.KindStevedoreOverride, stevedore.Overrides{})},
+		{Name: "env", Schema: kindSchema("Stevedore Env", stevedore.KindStevedoreEnv, stevedore.Env{})},
+		{Name: "ignore", Schema: rootSchema("Stevedore Ignore", Generate(stevedore.Ignores{}))},
+	}
+}
+
+// kindSchema returns the schema of the kind, whose kind and version are restricted to the supported ones
+func kindSchema(title, kind string, value interface{}) Schema {
+	schema := Generate(value)
+	properties := schema["properties"].(Schema)
+	properties["kind"] = Schema{"type": "string", "const": kind}
+	properties["version"] = Schema{"type": []string{"string", "integer"}, "enum": versions(kind)}
+	return rootSchema(title, schema)
+}
+
+// versions returns the supported versions of the kind, along with the integer ones as numbers
+// since yaml allows the version to be written without quotes
+func versions(kind string) []interface{} {
+	var result []interface{}
+	for _, version := range stevedore.SupportedVersions(kind) {
+		result = append(result, version)
+		if number, err := strconv.Atoi(version); err == nil {
+			result = append(result, number)
+		}
+	}
+	return result
+}
+
+func rootSchema(title string, schema Schema) Schema {
+	schema["$schema"] = draft
+	schema["title"] = title
+	return schema
+}
+
+// Generate returns the JSON Schema of the type of the value using its yaml and validate tags
+func Generate(value interface{}) Schema {
+	return generate(reflect.TypeOf(value))
+}
+
+func generate(valueType reflect.Type) Schema {
+	switch valueType {
+	case conditionsType:
+		return conditions()
+	case valuesType, substituteType, configsType:
+		return Schema{"type": "object"}
+	}
+
+	switch valueType.Kind() {
+	case reflect.Ptr:
+		return generate(valueType.Elem())
+	case reflect.Struct:
+		return object(valueType)
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": generate(valueType.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": generate(valueType.Elem())}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	}
+	return Schema{}
+}
+
+func object(structType reflect.Type) Schema {
+	properties := Schema{}
+	var required []string
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		properties[name] = generate(field.Type)
+		if hasRule(field, "required") {
+			required = append(required, name)
+		}
+	}
+
+	schema := Schema{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fieldName returns the name of the field in yaml, which defaults to the lower cased name of the field
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func hasRule(field reflect.StructField, rule string) bool {
+	for _, each := range strings.Split(field.Tag.Get("validate"), ",") {
+		if each == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// conditions returns the schema of the conditions, whose keys are the known conditions or the labels of the context,
+// and values are either a string or a list of strings
+func conditions() Schema {
+	condition := Schema{"type": "string"}
+	return Schema{
+		"type": "object",
+		"propertyNames": Schema{
+			"anyOf": []Schema{
+				{"enum": stevedore.KnownConditions()},
+				{"pattern": stevedore.LabelConditionPattern()},
+			},
+		},
+		"additionalProperties": Schema{
+			"oneOf": []Schema{condition, {"type": "array", "items": condition}},
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package schema_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGenerate(t *testing.T) {
+	type item struct {
+		Name     string            `yaml:"name" validate:"required"`
+		Internal string            `yaml:"-"`
+		Reason   string            `validate:"omitempty"`
+		Count    int32             `yaml:"count,omitempty"`
+		Tags     []string          `yaml:"tags"`
+		Labels   map[string]string `yaml:"labels"`
+		hidden   string
+	}
+	expected := schema.Schema{
+		"type": "object",
+		"properties": schema.Schema{
+			"name":   schema.Schema{"type": "string"},
+			"reason": schema.Schema{"type": "string"},
+			"count":  schema.Schema{"type": "integer"},
+			"tags":   schema.Schema{"type": "array", "items": schema.Schema{"type": "string"}},
+			"labels": schema.Schema{"type": "object", "additionalProperties": schema.Schema{"type": "string"}},
+		},
+		"additionalProperties": false,
+		"required":             []string{"name"},
+	}
+
+	assert.Equal(t, expected, schema.Generate(item{hidden: "hidden"}))
+}
+
+// TestSchemasAreInSync fails when the types of the stevedore yaml(s) drift from the committed schemas.
+// Regenerate them using `stevedore schema --output-dir schemas`
+func TestSchemasAreInSync(t *testing.T) {
+	for _, document := range schema.All() {
+		t.Run(document.Name, func(t *testing.T) {
+			expected, err := ioutil.ReadFile(filepath.Join("..", "..", "schemas", document.FileName()))
+			require.NoError(t, err)
+
+			actual, err := document.JSON()
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expected), string(actual), "schema is out of sync, regenerate it using `stevedore schema --output-dir schemas`")
+		})
+	}
+}
+
+func TestExamplesConformToSchemas(t *testing.T) {
+	examples := map[string]string{
+		"manifest": filepath.Join("..", "..", "examples", "apps", "redis.yaml"),
+		"override": filepath.Join("..", "..", "examples", "overrides", "redis.yaml"),
+		"env":      filepath.Join("..", "..", "examples", "envs", "redis.yaml"),
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			document, ok := schema.All().Find(name)
+			require.True(t, ok)
+
+			data, err := ioutil.ReadFile(example)
+			require.NoError(t, err)
+			var value interface{}
+			require.NoError(t, yaml.Unmarshal(data, &value))
+
+			result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(document.Schema), gojsonschema.NewGoLoader(jsonCompatible(value)))
+
+			require.NoError(t, err)
+			assert.Empty(t, result.Errors())
+		})
+	}
+
+	t.Run("should report unknown conditions", func(t *testing.T) {
+		document, _ := schema.All().Find("override")
+		value := map[string]interface{}{
+			"kind":    "StevedoreOverride",
+			"version": "2",
+			"spec":    []interface{}{map[string]interface{}{"matches": map[string]interface{}{"region": "sg"}, "values": map[string]interface{}{}}},
+		}
+
+		result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(document.Schema), gojsonschema.NewGoLoader(value))
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid())
+	})
+}
+
+func jsonCompatible(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, each := range value {
+			result[key.(string)] = jsonCompatible(each)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for _, each := range value {
+			result = append(result, jsonCompatible(each))
+		}
+		return result
+	}
+	return value
+}
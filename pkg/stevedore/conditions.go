@@ -1,6 +1,12 @@
 package stevedore
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
 
 const (
 	// ConditionEnvironmentType represents condition for environment type
@@ -13,8 +19,15 @@ const (
 	ConditionContextName = "contextName"
 	// ConditionApplicationName represents condition for application name
 	ConditionApplicationName = "applicationName"
+	// ConditionLabelPrefix is the prefix of the conditions for the labels of the context, eg: labels.region
+	ConditionLabelPrefix = "labels."
 )
 
+var labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// expressionPatterns caches the compiled regular expressions of the conditions by their expression
+var expressionPatterns sync.Map
+
 var defaultConditionWeights = Weights{}
 
 var knownCriteria = []string{
@@ -25,20 +38,198 @@ var knownCriteria = []string{
 	ConditionApplicationName,
 }
 
-// Conditions represents knownCriteria and its corresponding value
-type Conditions map[string]string
+// Conditions represents knownCriteria and its corresponding value, which is either a string or a list of strings.
+// A string value matches
+//   - exactly, eg: staging
+//   - as glob, if it contains *, ? or [, eg: prod-*
+//   - as regular expression, if it is enclosed in /, eg: /^prod-[0-9]+$/
+//   - anything but the rest of it, if it starts with !, eg: !prod-*
+//
+// A list of values matches if any of its values match and none of its negated values match,
+// eg: [staging, production] or [prod-*, "!prod-legacy"].
+// A condition on a label which the context does not have never matches, even if it is negated
+type Conditions map[string]interface{}
 
 func init() {
 	defaultConditionWeights = NewWeights(knownCriteria)
 }
 
+// KnownConditions returns the built-in conditions, which are not labels of the context
+func KnownConditions() []string {
+	return append([]string{}, knownCriteria...)
+}
+
+// LabelConditionPattern returns the regular expression which the conditions for the labels of the context match
+func LabelConditionPattern() string {
+	return "^" + regexp.QuoteMeta(ConditionLabelPrefix) + strings.TrimPrefix(labelNamePattern.String(), "^")
+}
+
+// LabelCondition returns the condition for the label of the context
+func LabelCondition(label string) string {
+	return ConditionLabelPrefix + label
+}
+
+// IsValidLabel returns true if the name can be used as label of the context
+func IsValidLabel(name string) bool {
+	return labelNamePattern.MatchString(name)
+}
+
+func isLabelCondition(criteria string) bool {
+	return strings.HasPrefix(criteria, ConditionLabelPrefix) && IsValidLabel(strings.TrimPrefix(criteria, ConditionLabelPrefix))
+}
+
+func isKnownCriteria(criteria string) bool {
+	return contains(knownCriteria, criteria) || isLabelCondition(criteria)
+}
+
+// NewConditionWeights returns the weights of the conditions by their precedence, highest first, which decides
+// the weight of overrides and envs. Built-in conditions which are not listed follow the listed ones in their
+// default order, and labels which are not listed do not add to the weight.
+// The default weights are returned if no precedence is given
+func NewConditionWeights(precedence []string) (Weights, error) {
+	if len(precedence) == 0 {
+		return defaultConditionWeights, nil
+	}
+
+	seen := map[string]bool{}
+	for _, criteria := range precedence {
+		if !isKnownCriteria(criteria) {
+			return Weights{}, fmt.Errorf("invalid condition %s in precedence", criteria)
+		}
+		if seen[criteria] {
+			return Weights{}, fmt.Errorf("condition %s is repeated in precedence", criteria)
+		}
+		seen[criteria] = true
+	}
+
+	// knownCriteria and the weights are ordered with the lowest precedence first
+	var order []string
+	for _, criteria := range knownCriteria {
+		if !seen[criteria] {
+			order = append(order, criteria)
+		}
+	}
+	for i := len(precedence) - 1; i >= 0; i-- {
+		order = append(order, precedence[i])
+	}
+	return NewWeights(order), nil
+}
+
 // Weight returns sum of weight of conditions
-func (conditions Conditions) Weight() int {
+func (conditions Conditions) Weight(weights Weights) int {
 	var criteria []string
 	for key := range conditions {
 		criteria = append(criteria, key)
 	}
-	return defaultConditionWeights.Sum(criteria)
+	return weights.Sum(criteria)
+}
+
+// Matches returns true if the value of the criteria matches the condition
+func (conditions Conditions) Matches(criteria, value string) bool {
+	condition, ok := conditions[criteria]
+	if !ok {
+		return false
+	}
+
+	expressions, isList := conditionExpressions(condition)
+	if !isList {
+		return matchExpression(expressions[0], value)
+	}
+
+	matched, hasPositive := false, false
+	for _, expression := range expressions {
+		if strings.HasPrefix(expression, "!") {
+			if !matchExpression(expression, value) {
+				return false
+			}
+			continue
+		}
+		hasPositive = true
+		matched = matched || matchExpression(expression, value)
+	}
+	return matched || !hasPositive
+}
+
+// conditionExpressions returns the expressions of the condition, and whether the condition is a list
+func conditionExpressions(condition interface{}) ([]string, bool) {
+	switch condition := condition.(type) {
+	case []interface{}:
+		expressions := make([]string, 0, len(condition))
+		for _, item := range condition {
+			expressions = append(expressions, fmt.Sprintf("%v", item))
+		}
+		return expressions, true
+	case []string:
+		return condition, true
+	default:
+		return []string{fmt.Sprintf("%v", condition)}, false
+	}
+}
+
+func matchExpression(expression, value string) bool {
+	if strings.HasPrefix(expression, "!") {
+		return !matchExpression(strings.TrimPrefix(expression, "!"), value)
+	}
+	if isRegexExpression(expression) {
+		pattern, err := compileExpression(expression)
+		return err == nil && pattern.MatchString(value)
+	}
+	if isGlobExpression(expression) {
+		matched, err := path.Match(expression, value)
+		return err == nil && matched
+	}
+	return expression == value
+}
+
+func isRegexExpression(expression string) bool {
+	return len(expression) > 1 && strings.HasPrefix(expression, "/") && strings.HasSuffix(expression, "/")
+}
+
+func regexExpression(expression string) string {
+	return fmt.Sprintf("^(?:%s)$", expression[1:len(expression)-1])
+}
+
+// compileExpression returns the compiled regular expression, compiling it only once for each expression
+func compileExpression(expression string) (*regexp.Regexp, error) {
+	if pattern, ok := expressionPatterns.Load(expression); ok {
+		return pattern.(*regexp.Regexp), nil
+	}
+	pattern, err := regexp.Compile(regexExpression(expression))
+	if err != nil {
+		return nil, err
+	}
+	expressionPatterns.Store(expression, pattern)
+	return pattern, nil
+}
+
+func isGlobExpression(expression string) bool {
+	return strings.ContainsAny(expression, "*?[")
+}
+
+func isValidCondition(condition interface{}) bool {
+	switch condition.(type) {
+	case []interface{}, []string:
+	case map[interface{}]interface{}, map[string]interface{}, nil:
+		return false
+	}
+
+	expressions, isList := conditionExpressions(condition)
+	if isList && len(expressions) == 0 {
+		return false
+	}
+	for _, expression := range expressions {
+		expression = strings.TrimPrefix(expression, "!")
+		if isRegexExpression(expression) {
+			if _, err := compileExpression(expression); err != nil {
+				return false
+			}
+		} else if isGlobExpression(expression) {
+			if _, err := path.Match(expression, ""); err != nil {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // Convert converts given condition to another based on the context
@@ -26,12 +26,42 @@ func TestConditionsWeight(t *testing.T) {
 			ConditionContextName:     "context-name",
 		}
 
-		weight := conditions.Weight()
+		weight := conditions.Weight(defaultConditionWeights)
 
 		assert.Equal(t, 31, weight)
 	})
 }
 
+func TestNewConditionWeights(t *testing.T) {
+	t.Run("should not add weight for labels by default", func(t *testing.T) {
+		weights, err := NewConditionWeights(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, Conditions{ConditionEnvironmentType: "staging", "labels.region": "eu-west"}.Weight(weights))
+	})
+
+	t.Run("should assign weight based on the precedence, followed by the unlisted conditions", func(t *testing.T) {
+		weights, err := NewConditionWeights([]string{ConditionApplicationName, "labels.region", ConditionContextName})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 32, Conditions{ConditionApplicationName: "x"}.Weight(weights))
+		assert.Equal(t, 16, Conditions{"labels.region": "eu-west"}.Weight(weights))
+		assert.Equal(t, 8, Conditions{ConditionContextName: "x"}.Weight(weights))
+		assert.Equal(t, 4, Conditions{ConditionContextType: "x"}.Weight(weights))
+		assert.Equal(t, 1, Conditions{ConditionEnvironmentType: "x"}.Weight(weights))
+		assert.Equal(t, 0, Conditions{"labels.team": "payments"}.Weight(weights))
+		assert.Equal(t, 16, Conditions{ConditionApplicationName: "x"}.Weight(defaultConditionWeights))
+	})
+
+	t.Run("should return error for unknown or repeated conditions", func(t *testing.T) {
+		_, err := NewConditionWeights([]string{"region"})
+		assert.EqualError(t, err, "invalid condition region in precedence")
+
+		_, err = NewConditionWeights([]string{"labels.region", "labels.region"})
+		assert.EqualError(t, err, "condition labels.region is repeated in precedence")
+	})
+}
+
 func TestConditionsConvert(t *testing.T) {
 	t.Run("should convert the condition based on the context", func(t *testing.T) {
 		conditions := Conditions{
@@ -55,3 +85,21 @@ func TestConditionsConvert(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestCompileExpression(t *testing.T) {
+	t.Run("should compile the regular expression once", func(t *testing.T) {
+		first, err := compileExpression("/^prod-[0-9]+$/")
+		assert.NoError(t, err)
+		second, err := compileExpression("/^prod-[0-9]+$/")
+		assert.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.True(t, second.MatchString("prod-1"))
+	})
+
+	t.Run("should return error for invalid regular expression", func(t *testing.T) {
+		_, err := compileExpression("/[/")
+
+		assert.Error(t, err)
+	})
+}
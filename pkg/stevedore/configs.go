@@ -1,6 +1,12 @@
 package stevedore
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gojek/stevedore/log"
 	"github.com/gojek/stevedore/pkg/config"
 	"github.com/gojek/stevedore/pkg/merger"
 )
@@ -8,23 +14,196 @@ import (
 // Configs represents different store from where configurations can be fetched
 type Configs map[string]interface{}
 
-// Fetch returns substitute
+// validateProviderPrecedence returns error if any of the providers is repeated in the precedence
+func validateProviderPrecedence(precedence []string) error {
+	seen := map[string]bool{}
+	for _, name := range precedence {
+		if seen[name] {
+			return fmt.Errorf("provider %s is repeated in precedence", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// ConfigConflict represents a key returned by more than one config provider with different values
+type ConfigConflict struct {
+	Key string
+	// Providers which returned the key, lowest precedence first
+	Providers []string
+}
+
+// Winner returns the provider whose value of the key is used
+func (conflict ConfigConflict) Winner() string {
+	return conflict.Providers[len(conflict.Providers)-1]
+}
+
+// String returns the conflict as a single line
+func (conflict ConfigConflict) String() string {
+	return fmt.Sprintf("%s is returned by providers %s, using the one from %s", conflict.Key, strings.Join(conflict.Providers, ", "), conflict.Winner())
+}
+
+// ConfigConflictError represents the error when the config providers return conflicting keys in strict mode
+type ConfigConflictError []ConfigConflict
+
+// Error returns the underlying error
+func (err ConfigConflictError) Error() string {
+	conflicts := make([]string, 0, len(err))
+	for _, conflict := range err {
+		conflicts = append(conflicts, conflict.String())
+	}
+	return fmt.Sprintf("conflicting configs: %s", strings.Join(conflicts, "; "))
+}
+
+// Fetch returns substitute. The configs are merged in the order of the provider precedence of the context,
+// and the conflicting keys fail the fetch if the context has strict configs, else each of them is warned about
+// once in the run of the cache of the providers
 func (configs Configs) Fetch(providers config.Providers, context Context) (Substitute, error) {
-	contextMap, err := context.Map()
+	pluginConfigs, _, err := configs.fetchAll(providers, context)
 	if err != nil {
 		return nil, err
 	}
+	return pluginConfigs.merge(context.ProviderPrecedence, context.StrictConfigs, providers.FirstReport)
+}
 
-	//TODO: Fix: Non Deterministic Plugin merge for same config keys
-	pluginConfigs, err := providers.Fetch(contextMap, configs)
+// MergeInto fetches the configs and merges them into the base values,
+// such that the merge directives in the configs are applied on the base values
+func (configs Configs) MergeInto(base Values, providers config.Providers, context Context) (Values, error) {
+	return configs.mergeInto(base, providers, context, nil)
+}
+
+// mergeInto merges the configs of each provider into the base values, calling merged with the values
+// before and after merging the configs of the provider, along with the keys of the configs which are sensitive
+func (configs Configs) mergeInto(base Values, providers config.Providers, context Context, merged func(provider string, before, after, config map[string]interface{}, sensitive []string)) (Values, error) {
+	pluginConfigs, sensitive, err := configs.fetchAll(providers, context)
 	if err != nil {
 		return nil, err
 	}
+	if len(pluginConfigs) == 0 {
+		return merger.Merge(base)
+	}
+
+	result := map[string]interface{}(base)
+	for _, name := range pluginConfigs.names(context.ProviderPrecedence) {
+		merge, err := merger.Merge(result, pluginConfigs[name])
+		if err != nil {
+			return nil, err
+		}
+		if merged != nil {
+			merged(name, result, merge, pluginConfigs[name], sensitive[name])
+		}
+		result = merge
+	}
+
+	return result, nil
+}
+
+// pluginConfigs represents the configs fetched by the name of the provider
+type pluginConfigs map[string]map[string]interface{}
+
+// sensitiveKeys represents the keys of the configs fetched by the name of the provider, whose values are sensitive
+type sensitiveKeys map[string][]string
+
+// contains returns true if the value of the key fetched by the provider is sensitive
+func (keys sensitiveKeys) contains(provider, key string) bool {
+	for _, each := range keys[provider] {
+		if each == key {
+			return true
+		}
+	}
+	return false
+}
+
+// names returns the names of the providers in the order of the given precedence, lowest first,
+// which is the order in which their configs are merged. Providers which are not in the precedence
+// precede the ones which are, in the reverse order of their names
+func (configs pluginConfigs) names(precedence []string) []string {
+	rank := make(map[string]int, len(precedence))
+	for index, name := range precedence {
+		rank[name] = len(precedence) - index
+	}
+
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if rank[names[i]] != rank[names[j]] {
+			return rank[names[i]] < rank[names[j]]
+		}
+		return names[i] > names[j]
+	})
+	return names
+}
+
+func (configs pluginConfigs) list(precedence []string) []map[string]interface{} {
+	names := configs.names(precedence)
+	pluginConfigList := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		pluginConfigList = append(pluginConfigList, configs[name])
+	}
+	return pluginConfigList
+}
+
+// conflicts returns the keys returned by more than one provider with different values
+func (configs pluginConfigs) conflicts(precedence []string) []ConfigConflict {
+	providers := map[string][]string{}
+	var keys []string
+	for _, name := range configs.names(precedence) {
+		for key := range configs[name] {
+			if _, ok := providers[key]; !ok {
+				keys = append(keys, key)
+			}
+			providers[key] = append(providers[key], name)
+		}
+	}
+	sort.Strings(keys)
 
-	var pluginConfigList []map[string]interface{}
-	for _, pluginConfig := range pluginConfigs {
-		pluginConfigList = append(pluginConfigList, pluginConfig)
+	var result []ConfigConflict
+	for _, key := range keys {
+		names := providers[key]
+		for _, name := range names[1:] {
+			if !reflect.DeepEqual(configs[names[0]][key], configs[name][key]) {
+				result = append(result, ConfigConflict{Key: key, Providers: names})
+				break
+			}
+		}
 	}
-	merge, err := merger.Merge(pluginConfigList...)
-	return merge, err
+	return result
+}
+
+// merge merges the configs in the order of the precedence of the providers.
+// Conflicting keys fail the merge in strict mode, else they are warned about, if firstReport returns true for them
+func (configs pluginConfigs) merge(precedence []string, strict bool, firstReport func(message string) bool) (map[string]interface{}, error) {
+	if conflicts := configs.conflicts(precedence); len(conflicts) != 0 {
+		if strict {
+			return nil, ConfigConflictError(conflicts)
+		}
+		for _, conflict := range conflicts {
+			if message := fmt.Sprintf("conflicting config %s", conflict); firstReport(message) {
+				log.Warn(message)
+			}
+		}
+	}
+	return merger.Merge(configs.list(precedence)...)
+}
+
+// source returns the source of the variable from the provider with the highest precedence which has it
+func (configs pluginConfigs) source(precedence []string, variable string) (Source, bool) {
+	names := configs.names(precedence)
+	for index := len(names) - 1; index >= 0; index-- {
+		if _, ok := configs[names[index]][variable]; ok {
+			return Source{Type: ConfigSource, Provider: names[index], Variable: variable}, true
+		}
+	}
+	return Source{}, false
+}
+
+func (configs Configs) fetchAll(providers config.Providers, context Context) (pluginConfigs, sensitiveKeys, error) {
+	contextMap, err := context.Map()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return providers.FetchWithSensitiveKeys(contextMap, configs)
 }
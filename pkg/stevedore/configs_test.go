@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gojek/stevedore/client/provider"
+	"github.com/gojek/stevedore/pkg/config"
 	"github.com/gojek/stevedore/pkg/internal/mocks/plugin"
 	pkgPlugin "github.com/gojek/stevedore/pkg/plugin"
 	"github.com/gojek/stevedore/pkg/stevedore"
@@ -90,3 +91,66 @@ func TestConfigsFetch(t *testing.T) {
 		assert.Nil(t, substitutes)
 	})
 }
+
+type staticProvider map[string]interface{}
+
+func (provider staticProvider) Fetch(map[string]string, interface{}) (map[string]interface{}, error) {
+	return provider, nil
+}
+
+func TestConfigsFetchPrecedence(t *testing.T) {
+	providers := config.Providers{
+		{Name: "store", Provider: staticProvider{"name": "from-store", "port": 8080}},
+		{Name: "vault", Provider: staticProvider{"name": "from-vault", "port": 8080, "password": "secret"}},
+		{Name: "file", Provider: staticProvider{"name": "from-file"}},
+	}
+	configs := stevedore.Configs{"store": nil, "vault": nil, "file": nil}
+
+	t.Run("should use the provider which comes first by name when no precedence is set", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			substitute, err := configs.Fetch(providers, stevedore.Context{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, stevedore.Substitute{"name": "from-file", "port": 8080, "password": "secret"}, substitute)
+		}
+	})
+
+	t.Run("should use the provider with the highest precedence", func(t *testing.T) {
+		substitute, err := configs.Fetch(providers, stevedore.Context{ProviderPrecedence: []string{"vault", "file"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"name": "from-vault", "port": 8080, "password": "secret"}, substitute)
+	})
+
+	t.Run("should fail on conflicting keys in strict mode", func(t *testing.T) {
+		_, err := configs.Fetch(providers, stevedore.Context{ProviderPrecedence: []string{"vault"}, StrictConfigs: true})
+
+		expected := stevedore.ConfigConflictError{{Key: "name", Providers: []string{"store", "file", "vault"}}}
+		assert.Equal(t, expected, err)
+		assert.EqualError(t, err, "conflicting configs: name is returned by providers store, file, vault, using the one from vault")
+	})
+}
+
+func TestConfigsMergeInto(t *testing.T) {
+	t.Run("should apply merge directives of fetched configs on base values", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pluginResponse := map[string]interface{}{
+			"env": map[interface{}]interface{}{"$append": []interface{}{"DEBUG"}},
+		}
+		configProvider := mockPlugin.NewMockConfigInterface(ctrl)
+		configProvider.EXPECT().Type().Return(pkgPlugin.TypeConfig, nil)
+		configProvider.EXPECT().Fetch(gomock.Any(), gomock.Any()).Return(pluginResponse, nil)
+		plugins := provider.Plugins{"store": provider.ClientPlugin{PluginImpl: configProvider}}
+		configProviders, _ := plugins.ConfigProviders()
+
+		configs := stevedore.Configs{"store": []map[string]interface{}{}}
+		base := stevedore.Values{"env": []interface{}{"LOG_LEVEL"}}
+
+		actual, err := configs.MergeInto(base, configProviders, stevedore.Context{Environment: "staging"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Values{"env": []interface{}{"LOG_LEVEL", "DEBUG"}}, actual)
+	})
+}
@@ -21,10 +21,13 @@ const defaultDirMode = 0770
 
 // Configuration config to wrap all stevedore contexts and store config
 type Configuration struct {
-	Contexts Contexts `yaml:"contexts"`
-	Current  string   `yaml:"current"`
-	fs       afero.Fs
-	filename string
+	Contexts            Contexts `yaml:"contexts"`
+	Current             string   `yaml:"current"`
+	ConditionPrecedence []string `yaml:"conditionPrecedence,omitempty"`
+	ProviderPrecedence  []string `yaml:"providerPrecedence,omitempty"`
+	StrictConfigs       bool     `yaml:"strictConfigs,omitempty"`
+	fs                  afero.Fs
+	filename            string
 }
 
 // AppConfigStore to fetch configurations specific to release specifications
@@ -123,11 +126,38 @@ func (s *Configuration) CurrentContext() (Context, error) {
 		return Context{}, fmt.Errorf("current context is not set")
 	}
 	if index, ok := s.Contexts.Find(s.Current); ok {
-		return s.Contexts[index], nil
+		return s.configure(s.Contexts[index])
 	}
 	return Context{}, fmt.Errorf("unable to find current context %v", s.Current)
 }
 
+// ConfiguredContexts returns all the contexts along with the settings of the configuration, such as the precedence of the conditions and providers
+func (s *Configuration) ConfiguredContexts() (Contexts, error) {
+	contexts := make(Contexts, 0, len(s.Contexts))
+	for _, ctx := range s.Contexts {
+		configured, err := s.configure(ctx)
+		if err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, configured)
+	}
+	return contexts, nil
+}
+
+// configure returns the context along with the settings of the configuration
+func (s *Configuration) configure(ctx Context) (Context, error) {
+	if _, err := NewConditionWeights(s.ConditionPrecedence); err != nil {
+		return Context{}, err
+	}
+	if err := validateProviderPrecedence(s.ProviderPrecedence); err != nil {
+		return Context{}, err
+	}
+	ctx.ConditionPrecedence = s.ConditionPrecedence
+	ctx.ProviderPrecedence = s.ProviderPrecedence
+	ctx.StrictConfigs = s.StrictConfigs
+	return ctx, nil
+}
+
 func (s *Configuration) save() error {
 	dir, _ := filepath.Split(s.filename)
 	err := s.fs.MkdirAll(dir, defaultDirMode)
@@ -3,6 +3,8 @@ package stevedore
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -16,6 +18,27 @@ type Context struct {
 	KubernetesContext string `yaml:"kubernetesContext" validate:"required"`
 	EnvironmentType   string `yaml:"environmentType" validate:"required"`
 	KubeConfigFile    string `yaml:"kubeConfigFile"`
+	Labels            Labels `yaml:"labels,omitempty" validate:"labels"`
+	// ConditionPrecedence is the precedence of the conditions in the configuration, which is not stored in the context
+	ConditionPrecedence []string `yaml:"-" json:"-"`
+	// ProviderPrecedence is the precedence of the config providers in the configuration, which is not stored in the context
+	ProviderPrecedence []string `yaml:"-" json:"-"`
+	// StrictConfigs is whether the conflicting configs fail the fetch, as set in the configuration
+	StrictConfigs bool `yaml:"-" json:"-"`
+}
+
+// Labels represents the arbitrary labels of the context, such as region or team,
+// which can be matched using the conditions prefixed with ConditionLabelPrefix
+type Labels map[string]string
+
+// String returns the labels as comma separated key=value pairs sorted by key
+func (labels Labels) String() string {
+	var pairs []string
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
 }
 
 // IsValid validates the context and returns error if any
@@ -23,8 +46,10 @@ func (ctx Context) IsValid() error {
 	return Validate(ctx)
 }
 
-// Map converts Context to a map[string]string
+// Map converts Context to a map[string]string, with the labels keyed by their conditions
 func (ctx Context) Map() (map[string]string, error) {
+	labels := ctx.Labels
+	ctx.Labels = nil
 	data, err := yaml.Marshal(ctx)
 	if err != nil {
 		return nil, err
@@ -34,6 +59,9 @@ func (ctx Context) Map() (map[string]string, error) {
 	if err := yaml.Unmarshal(data, &mapToreturn); err != nil {
 		return nil, err
 	}
+	for key, value := range labels {
+		mapToreturn[LabelCondition(key)] = value
+	}
 	return mapToreturn, nil
 }
 
@@ -47,10 +75,23 @@ func (ctx Context) String() string {
 	buff.WriteString(fmt.Sprintf("\nKubernetes Context: %s", ctx.KubernetesContext))
 	buff.WriteString(fmt.Sprintf("\nEnvironment Type: %s", ctx.EnvironmentType))
 	buff.WriteString(fmt.Sprintf("\nKubeConfig File: %s", ctx.KubeConfigFile))
+	if len(ctx.Labels) != 0 {
+		buff.WriteString(fmt.Sprintf("\nLabels: %s", ctx.Labels))
+	}
 	buff.WriteString("\n------------------")
 	return buff.String()
 }
 
+// ConditionWeights returns the weights of the conditions by the precedence of the context.
+// As the precedence is validated while loading the configuration, the default weights are returned if it is invalid
+func (ctx Context) ConditionWeights() Weights {
+	weights, err := NewConditionWeights(ctx.ConditionPrecedence)
+	if err != nil {
+		return defaultConditionWeights
+	}
+	return weights
+}
+
 // Conditions returns Conditions
 func (ctx Context) Conditions() Conditions {
 	conditions := Conditions{}
@@ -58,6 +99,9 @@ func (ctx Context) Conditions() Conditions {
 	conditions[ConditionEnvironmentType] = ctx.EnvironmentType
 	conditions[ConditionContextName] = ctx.Name
 	conditions[ConditionContextType] = ctx.Type
+	for key, value := range ctx.Labels {
+		conditions[LabelCondition(key)] = value
+	}
 	return conditions
 }
 
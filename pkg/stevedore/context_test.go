@@ -63,6 +63,12 @@ func TestContextValid(t *testing.T) {
 		},
 	}
 
+	emptyScenarios = append(emptyScenarios, scenario{
+		name:         "label name is invalid",
+		context:      Context{Name: "components", KubernetesContext: "components", Environment: "env", EnvironmentType: "staging", Labels: Labels{"region!": "eu"}},
+		errorMessage: "Key: 'Context.Labels' Error:Field validation for 'Labels' failed on the 'labels' tag",
+	})
+
 	for _, s := range emptyScenarios {
 		t.Run(fmt.Sprintf("should return error if %s", s.name), func(t *testing.T) {
 			err := s.context.IsValid()
@@ -92,6 +98,14 @@ KubeConfig File: ~/.kube/configs/minikube
 
 		assert.Equal(t, expected, content)
 	})
+
+	t.Run("it should return context with labels as string", func(t *testing.T) {
+		context := Context{Name: "components", Labels: Labels{"team": "payments", "region": "eu-west"}}
+
+		content := context.String()
+
+		assert.Contains(t, content, "\nLabels: region=eu-west, team=payments\n")
+	})
 }
 
 func TestContextConditions(t *testing.T) {
@@ -102,6 +116,13 @@ func TestContextConditions(t *testing.T) {
 
 		assert.Equal(t, expected, conditions)
 	})
+
+	t.Run("it should return conditions with labels", func(t *testing.T) {
+		context := Context{Name: "components", Type: "services", EnvironmentType: "staging", Environment: "env", Labels: Labels{"region": "eu-west"}}
+		expected := Conditions{ConditionContextName: "components", ConditionContextType: "services", ConditionEnvironmentType: "staging", ConditionEnvironment: "env", "labels.region": "eu-west"}
+
+		assert.Equal(t, expected, context.Conditions())
+	})
 }
 
 func TestContextMap(t *testing.T) {
@@ -113,3 +134,13 @@ func TestContextMap(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestContextMapWithLabels(t *testing.T) {
+	context := Context{Name: "components", Type: "services", EnvironmentType: "staging", KubernetesContext: "components", Environment: "env", Labels: Labels{"region": "eu-west"}}
+	expected := map[string]string{"name": "components", "type": "services", "environmentType": "staging", "kubernetesContext": "components", "environment": "env", "kubeConfigFile": "", "labels.region": "eu-west"}
+
+	actual, err := context.Map()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
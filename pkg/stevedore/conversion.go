@@ -0,0 +1,180 @@
+package stevedore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"gopkg.in/yaml.v2"
+)
+
+// Document represents a stevedore yaml before it is decoded into its kind
+type Document map[string]interface{}
+
+// Kind returns the kind of the document
+func (document Document) Kind() string {
+	return fmt.Sprintf("%v", document["kind"])
+}
+
+// Version returns the version of the document
+func (document Document) Version() string {
+	if version, ok := document["version"]; ok && version != nil {
+		return fmt.Sprintf("%v", version)
+	}
+	return ""
+}
+
+// Converter converts the document of a kind from a version to the next version
+type Converter struct {
+	Kind    string
+	From    string
+	To      string
+	Convert func(document Document) (Document, error)
+}
+
+// Converters represents collection of Converter
+type Converters []Converter
+
+// converters holds the converters between the versions of the stevedore yamls.
+// Whenever the schema of a kind changes, its current version has to be bumped
+// along with a converter from the previous version
+var converters = Converters{}
+
+// RegisterConverter adds the converter of a kind from a version to the next version,
+// which is applied while decoding the stevedore yamls
+func RegisterConverter(converter Converter) {
+	converters = append(converters, converter)
+}
+
+// ConvertToCurrentVersion returns the reader of the yaml converted to the current version of its kind,
+// as it is done while decoding the stevedore yamls
+func ConvertToCurrentVersion(reader io.Reader) (io.Reader, error) {
+	return converters.convert(reader)
+}
+
+// UnsupportedVersionError represents the error when a document can not be converted to the current version of its kind
+type UnsupportedVersionError struct {
+	Kind      string
+	Version   string
+	Supported []string
+}
+
+// Error returns the underlying error
+func (err UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("version %s of %s is not supported. Supported version(s) are %s", err.Version, err.Kind, strings.Join(err.Supported, ", "))
+}
+
+// CurrentVersion returns the current version of the kind
+func CurrentVersion(kind string) string {
+	switch kind {
+	case KindStevedoreManifest:
+		return ManifestCurrentVersion
+	case KindStevedoreOverride:
+		return OverrideCurrentVersion
+	case KindStevedoreEnv:
+		return EnvCurrentVersion
+	}
+	return ""
+}
+
+// IsCurrentVersion returns true if the version is the current version of the kind
+func IsCurrentVersion(kind, version string) bool {
+	return sameVersion(version, CurrentVersion(kind))
+}
+
+// SupportedVersions returns the versions of the kind which can be decoded
+func SupportedVersions(kind string) []string {
+	return converters.SupportedVersions(kind)
+}
+
+// SupportedVersions returns the current version of the kind along with the versions it can be converted from
+func (converters Converters) SupportedVersions(kind string) []string {
+	result := []string{CurrentVersion(kind)}
+	for _, converter := range converters {
+		if converter.Kind == kind {
+			result = append(result, converter.From)
+		}
+	}
+	return result
+}
+
+// Convert converts the document to the current version of its kind by applying the converters one version at a time.
+// Documents of unknown kinds are returned as is
+func (converters Converters) Convert(document Document) (Document, error) {
+	kind := document.Kind()
+	current := CurrentVersion(kind)
+	if current == "" {
+		return document, nil
+	}
+
+	visited := map[string]struct{}{}
+	for version := document.Version(); !sameVersion(version, current); version = document.Version() {
+		converter, ok := converters.find(kind, version)
+		if _, seen := visited[version]; !ok || seen {
+			return nil, UnsupportedVersionError{Kind: kind, Version: version, Supported: converters.SupportedVersions(kind)}
+		}
+		visited[version] = struct{}{}
+
+		converted, err := converter.Convert(document)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert %s from version %s to %s: %v", kind, converter.From, converter.To, err)
+		}
+		converted["version"] = converter.To
+		document = converted
+	}
+	return document, nil
+}
+
+func (converters Converters) find(kind, version string) (Converter, bool) {
+	for _, converter := range converters {
+		if converter.Kind == kind && sameVersion(converter.From, version) {
+			return converter, true
+		}
+	}
+	return Converter{}, false
+}
+
+func sameVersion(version, other string) bool {
+	if version == other {
+		return true
+	}
+	semVersion, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	otherSemVersion, err := semver.NewVersion(other)
+	if err != nil {
+		return false
+	}
+	return semVersion.Equal(otherSemVersion)
+}
+
+// convert returns the reader of the yaml converted to the current version of its kind.
+// Yaml which are not documents of a known kind or do not have a version are returned as is, to be reported by the validation
+func (converters Converters) convert(reader io.Reader) (io.Reader, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	document := Document{}
+	if err := yaml.Unmarshal(data, &document); err != nil || CurrentVersion(document.Kind()) == "" {
+		return bytes.NewReader(data), nil
+	}
+	if version := document.Version(); version == "" || sameVersion(version, CurrentVersion(document.Kind())) {
+		return bytes.NewReader(data), nil
+	}
+
+	converted, err := converters.Convert(document)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,82 @@
+package stevedore_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertersConvert(t *testing.T) {
+	converters := stevedore.Converters{
+		{Kind: stevedore.KindStevedoreManifest, From: "0", To: "1", Convert: func(document stevedore.Document) (stevedore.Document, error) {
+			document["deployTo"] = []interface{}{map[interface{}]interface{}{"environment": document["environment"]}}
+			delete(document, "environment")
+			return document, nil
+		}},
+		{Kind: stevedore.KindStevedoreManifest, From: "1", To: "2", Convert: func(document stevedore.Document) (stevedore.Document, error) {
+			document["spec"] = document["releases"]
+			delete(document, "releases")
+			return document, nil
+		}},
+		{Kind: stevedore.KindStevedoreEnv, From: "1", To: "2", Convert: func(document stevedore.Document) (stevedore.Document, error) {
+			return nil, fmt.Errorf("some error")
+		}},
+	}
+
+	t.Run("should convert the document to the current version one version at a time", func(t *testing.T) {
+		document := stevedore.Document{"kind": stevedore.KindStevedoreManifest, "version": "0", "environment": "staging", "releases": []interface{}{}}
+		expected := stevedore.Document{
+			"kind":     stevedore.KindStevedoreManifest,
+			"version":  "2",
+			"deployTo": []interface{}{map[interface{}]interface{}{"environment": "staging"}},
+			"spec":     []interface{}{},
+		}
+
+		actual, err := converters.Convert(document)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should not convert the document of the current version or an unknown kind", func(t *testing.T) {
+		current := stevedore.Document{"kind": stevedore.KindStevedoreManifest, "version": "2.0", "releases": []interface{}{}}
+		unknown := stevedore.Document{"kind": "Unknown", "version": "0"}
+
+		actualCurrent, err := converters.Convert(current)
+		assert.NoError(t, err)
+		assert.Equal(t, current, actualCurrent)
+
+		actualUnknown, err := converters.Convert(unknown)
+		assert.NoError(t, err)
+		assert.Equal(t, unknown, actualUnknown)
+	})
+
+	t.Run("should fail to convert the unsupported version", func(t *testing.T) {
+		_, err := converters.Convert(stevedore.Document{"kind": stevedore.KindStevedoreManifest, "version": "3"})
+
+		assert.Equal(t, stevedore.UnsupportedVersionError{Kind: stevedore.KindStevedoreManifest, Version: "3", Supported: []string{"2", "0", "1"}}, err)
+		assert.EqualError(t, err, "version 3 of StevedoreManifest is not supported. Supported version(s) are 2, 0, 1")
+	})
+
+	t.Run("should fail when the converter fails", func(t *testing.T) {
+		_, err := converters.Convert(stevedore.Document{"kind": stevedore.KindStevedoreEnv, "version": "1"})
+
+		assert.EqualError(t, err, "unable to convert StevedoreEnv from version 1 to 2: some error")
+	})
+}
+
+func TestNewManifestWithUnsupportedVersion(t *testing.T) {
+	manifest := `kind: StevedoreManifest
+version: "3"
+deployTo:
+- environment: staging
+spec: []
+`
+
+	_, err := stevedore.NewManifest(strings.NewReader(manifest))
+
+	assert.Equal(t, stevedore.UnsupportedVersionError{Kind: stevedore.KindStevedoreManifest, Version: "3", Supported: []string{"2"}}, err)
+}
@@ -0,0 +1,97 @@
+package stevedore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gojek/stevedore/log"
+	"github.com/gojek/stevedore/pkg/helm"
+)
+
+// DependentFailedError represents a release which is not uninstalled
+// as one or more of the releases depending on it have failed to uninstall
+type DependentFailedError struct {
+	ReleaseName string
+	Dependents  []string
+}
+
+// Error returns the reason for skipping the release
+func (err DependentFailedError) Error() string {
+	return fmt.Sprintf("skipped %s as the release(s) depending on it failed to uninstall: %v", err.ReleaseName, err.Dependents)
+}
+
+// CreateDestroyResponse will take the manifests and produce response by uninstalling the releases based on given Opts
+func CreateDestroyResponse(ctx context.Context, client helm.Client, manifestFiles ManifestFiles, opts Opts, helmTimeout int64) (Responses, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request aborted abruptly by client")
+	default:
+		s := Stevedore{Client: client, Opts: opts}
+		return s.Destroy(ctx, manifestFiles, helmTimeout)
+	}
+}
+
+// Destroy uninstalls releases one after another, such that every release is uninstalled
+// before the releases it depends on. A release is skipped if any release depending on it fails
+// to uninstall. It returns DependencyCycleError without uninstalling any release if dependsOn has a cycle
+func (s Stevedore) Destroy(ctx context.Context, manifestFiles ManifestFiles, helmTimeout int64) (Responses, error) {
+	graph, err := NewReleaseGraph(manifestFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := graph.sorted()
+	failed := make([]bool, len(graph.Nodes))
+	var acc Responses
+	for position := len(sorted) - 1; position >= 0; position-- {
+		index := sorted[position]
+		node := graph.Nodes[index]
+
+		var failedDependents []string
+		for _, dependent := range graph.dependents[index] {
+			if failed[dependent] {
+				failedDependents = append(failedDependents, graph.Nodes[dependent].Release.Name)
+			}
+		}
+		if len(failedDependents) != 0 {
+			err := DependentFailedError{ReleaseName: node.Release.Name, Dependents: failedDependents}
+			log.Error(err.Error())
+			failed[index] = true
+			acc = append(acc, failedResponse(node.File, node.ReleaseSpecification, err))
+			continue
+		}
+
+		response := s.uninstall(ctx, node, helmTimeout)
+		failed[index] = response.Err != nil
+		if !s.Filter || (response.HasDiff || response.Err != nil) {
+			acc = append(acc, response)
+		}
+	}
+	return acc, nil
+}
+
+func (s Stevedore) uninstall(ctx context.Context, node ReleaseNode, helmTimeout int64) Response {
+	release := node.Release
+	uninstallResponse, err := s.Client.Uninstall(ctx, release.Name, release.Namespace, s.DryRun, helmTimeout)
+	if err != nil {
+		return Response{
+			node.File,
+			release.Name,
+			release.Chart,
+			release.ChartVersion,
+			release.CurrentReleaseVersion,
+			uninstallResponse,
+			releaseError("uninstalling", release.Name, err),
+		}
+	}
+
+	return Response{
+		node.File,
+		release.Name,
+		release.Chart,
+		uninstallResponse.ChartVersion,
+		uninstallResponse.CurrentReleaseVersion,
+		uninstallResponse,
+		nil,
+	}
+}
@@ -0,0 +1,111 @@
+package stevedore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	mocks "github.com/gojek/stevedore/pkg/internal/mocks/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStevedoreDestroy(t *testing.T) {
+	var timeout int64 = 10
+	releaseSpecification := func(name string, dependsOn ...string) stevedore.ReleaseSpecification {
+		return stevedore.ReleaseSpecification{
+			Release:   stevedore.Release{Name: name, Namespace: "default", Chart: "chart/" + name},
+			DependsOn: dependsOn,
+		}
+	}
+	manifestFiles := stevedore.ManifestFiles{
+		{File: "app.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+			releaseSpecification("app", "db"),
+		}}},
+		{File: "infra.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+			releaseSpecification("db"),
+			releaseSpecification("cache"),
+		}}},
+	}
+
+	t.Run("should uninstall releases before the releases they depend on", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+
+		gomock.InOrder(
+			client.EXPECT().Uninstall(context.TODO(), "cache", "default", false, timeout).Return(helm.UpstallResponse{HasDiff: true, ChartVersion: "1.0.0", CurrentReleaseVersion: 2}, nil),
+			client.EXPECT().Uninstall(context.TODO(), "app", "default", false, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil),
+			client.EXPECT().Uninstall(context.TODO(), "db", "default", false, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil),
+		)
+
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{}}
+		responses, err := s.Destroy(context.TODO(), manifestFiles, timeout)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cache", "app", "db"}, responses.GetReleaseNames())
+		assert.Equal(t, stevedore.Response{
+			File:                  "infra.yaml",
+			ReleaseName:           "cache",
+			ChartName:             "chart/cache",
+			ChartVersion:          "1.0.0",
+			CurrentReleaseVersion: 2,
+			UpstallResponse:       helm.UpstallResponse{HasDiff: true, ChartVersion: "1.0.0", CurrentReleaseVersion: 2},
+		}, responses[0])
+	})
+
+	t.Run("should skip releases which the failed release depends on", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+
+		client.EXPECT().Uninstall(context.TODO(), "cache", "default", false, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil)
+		client.EXPECT().Uninstall(context.TODO(), "app", "default", false, timeout).Return(helm.UpstallResponse{}, fmt.Errorf("timed out"))
+
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{}}
+		responses, err := s.Destroy(context.TODO(), manifestFiles, timeout)
+
+		assert.NoError(t, err)
+		if assert.Len(t, responses, 3) {
+			assert.Equal(t, fmt.Errorf("error when uninstalling app due to timed out"), responses[1].Err)
+			assert.Equal(t, stevedore.DependentFailedError{ReleaseName: "db", Dependents: []string{"app"}}, responses[2].Err)
+			assert.Equal(t, "skipped db as the release(s) depending on it failed to uninstall: [app]", responses[2].Err.Error())
+		}
+	})
+
+	t.Run("should only list releases having resources on dry run with filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+
+		client.EXPECT().Uninstall(context.TODO(), "cache", "default", true, timeout).Return(helm.UpstallResponse{}, nil)
+		client.EXPECT().Uninstall(context.TODO(), "app", "default", true, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil)
+		client.EXPECT().Uninstall(context.TODO(), "db", "default", true, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil)
+
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{DryRun: true, Filter: true}}
+		responses, err := s.Destroy(context.TODO(), manifestFiles, timeout)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"app", "db"}, responses.GetReleaseNames())
+	})
+
+	t.Run("should return error without uninstalling when dependsOn has a cycle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+
+		cyclic := stevedore.ManifestFiles{
+			{File: "app.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("app", "db"),
+				releaseSpecification("db", "app"),
+			}}},
+		}
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{}}
+		responses, err := s.Destroy(context.TODO(), cyclic, timeout)
+
+		assert.Nil(t, responses)
+		assert.IsType(t, stevedore.DependencyCycleError{}, err)
+	})
+}
@@ -25,8 +25,9 @@ func (env Env) IsValid() error {
 
 // EnvSpecification represents specification for env
 type EnvSpecification struct {
-	Matches Conditions `validate:"criteria" yaml:"matches" json:"matches"`
-	Values  Substitute `yaml:"env" json:"env"`
+	Matches   Conditions         `validate:"criteria" yaml:"matches" json:"matches"`
+	Values    Substitute         `yaml:"env" json:"env"`
+	Sensitive SensitiveVariables `yaml:"sensitive,omitempty" json:"sensitive,omitempty"`
 }
 
 // IsApplicableFor returns true if Manifest is applicable for the given environment
@@ -39,14 +40,14 @@ func (env EnvSpecification) IsApplicableFor(context Context) bool {
 type EnvSpecifications []EnvSpecification
 
 // weight returns the weight of the envs
-func (env EnvSpecification) weight() int {
-	return env.Matches.Weight()
+func (env EnvSpecification) weight(weights Weights) int {
+	return env.Matches.Weight(weights)
 }
 
-// Sort will sort the envs based on the pre-determined order
-func (envs EnvSpecifications) Sort() {
+// Sort will sort the envs based on the weights of their conditions
+func (envs EnvSpecifications) Sort(weights Weights) {
 	sort.SliceStable(envs, func(i, j int) bool {
-		return envs[i].weight() < envs[j].weight()
+		return envs[i].weight(weights) < envs[j].weight(weights)
 	})
 }
 
@@ -60,8 +61,13 @@ func (env Env) Format(f fmt.State, c rune) {
 
 // NewEnv to Validate the Stevedore env configuration
 func NewEnv(reader io.Reader) (Env, error) {
+	reader, err := converters.convert(reader)
+	if err != nil {
+		return Env{}, err
+	}
+
 	env := Env{}
-	err := yaml.NewDecoder(reader).Decode(&env)
+	err = yaml.NewDecoder(reader).Decode(&env)
 	if err != nil {
 		return Env{}, fmt.Errorf("[NewEnv] error when validating from file:\n%v", err)
 	}
@@ -243,7 +243,7 @@ func TestEnvSpecificationsSort(t *testing.T) {
 			},
 		}
 
-		envs.Sort()
+		envs.Sort(stevedore.Context{}.ConditionWeights())
 
 		assert.Equal(t, expectedEnvs, envs)
 	})
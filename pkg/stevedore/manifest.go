@@ -18,9 +18,9 @@ type Manifest struct {
 }
 
 // EnrichWith will return enriched manifest with final merged values
-func (manifest Manifest) EnrichWith(context Context, overrides Overrides) Manifest {
-	enrichedApplications := manifest.Spec.EnrichWith(context, overrides)
-	return Manifest{DeployTo: manifest.DeployTo, Spec: enrichedApplications}
+func (manifest Manifest) EnrichWith(context Context, overrides Overrides) (Manifest, error) {
+	enrichedApplications, err := manifest.Spec.EnrichWith(context, overrides)
+	return Manifest{DeployTo: manifest.DeployTo, Spec: enrichedApplications}, err
 }
 
 // Replace will return manifest with substituted values
@@ -55,6 +55,11 @@ func (manifest Manifest) Format(f fmt.State, c rune) {
 
 // NewManifest to Validate the Stevedore manifest configuration
 func NewManifest(reader io.Reader) (*Manifest, error) {
+	reader, err := converters.convert(reader)
+	if err != nil {
+		return nil, err
+	}
+
 	manifest := &Manifest{}
 	if err := ValidateAndGenerate(reader, manifest); err != nil {
 		return nil, fmt.Errorf("[Schema Validation Failed] error when validating from file:\n%v", err)
@@ -2,6 +2,7 @@ package stevedore
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/gojek/stevedore/pkg/config"
 
@@ -78,6 +79,22 @@ func (manifestFiles ManifestFiles) Filter(ignores Ignores, context Context) (Man
 	return result, ignoreComponents
 }
 
+// TrackProvenance returns the manifests which track the origin of each leaf of the values
+// while being enriched with overrides, substitutes and mounts
+func (manifestFiles ManifestFiles) TrackProvenance() ManifestFiles {
+	result := make(ManifestFiles, 0, len(manifestFiles))
+	for _, manifestFile := range manifestFiles {
+		specs := make(ReleaseSpecifications, 0, len(manifestFile.Spec))
+		for _, spec := range manifestFile.Spec {
+			spec.Release.provenance = newProvenance(spec.Release.Values, Source{Type: ManifestSource, File: manifestFile.File})
+			specs = append(specs, spec)
+		}
+		manifestFile.Spec = specs
+		result = append(result, manifestFile)
+	}
+	return result
+}
+
 // Enrich filters the applicable manifest, enriches it with overrides and substitutes
 func (manifestFiles ManifestFiles) Enrich(
 	overrides Overrides,
@@ -90,14 +107,24 @@ func (manifestFiles ManifestFiles) Enrich(
 	manifestErrors := file.Errors{}
 	filteredManifests, ignoredComponents := manifestFiles.Filter(ignores, stevedoreContext)
 
+	enrichedManifests := make(ManifestFiles, 0, len(filteredManifests))
 	for _, manifest := range filteredManifests {
-		enrichedManifest := manifest.EnrichWith(stevedoreContext, overrides)
-		populatedManifest, err := enrichedManifest.Replace(stevedoreContext, envs, providers)
+		enrichedManifest, err := manifest.EnrichWith(stevedoreContext, overrides)
+		if err != nil {
+			return filteredManifests, ignoredComponents, file.Errors{file.Error{Filename: manifest.File, Reason: err}}
+		}
+		enrichedManifests = append(enrichedManifests, ManifestFile{File: manifest.File, Manifest: enrichedManifest})
+	}
+	enrichedManifests.prefetch(stevedoreContext, providers)
+
+	for _, manifest := range enrichedManifests {
+		manifestProviders := providers.RelativeTo(filepath.Dir(manifest.File))
+		populatedManifest, err := manifest.Replace(stevedoreContext, envs, manifestProviders)
 		if err != nil {
 			manifestErrors = append(manifestErrors, file.Error{Filename: manifest.File, Reason: err})
 		}
 
-		mountedManifest, err := populatedManifest.Mount(stevedoreContext, providers)
+		mountedManifest, err := populatedManifest.Mount(stevedoreContext, manifestProviders)
 
 		if err != nil {
 			manifestErrors = append(manifestErrors, file.Error{Filename: manifest.File, Reason: err})
@@ -110,6 +137,28 @@ func (manifestFiles ManifestFiles) Enrich(
 	return result, ignoredComponents, nil
 }
 
+// prefetch fetches the configs and mounts of all the releases concurrently, ahead of their substitution
+func (manifestFiles ManifestFiles) prefetch(stevedoreContext Context, providers config.Providers) {
+	contextMap, err := stevedoreContext.Map()
+	if err != nil {
+		return
+	}
+
+	var requests []config.Request
+	for _, manifestFile := range manifestFiles {
+		manifestProviders := providers.RelativeTo(filepath.Dir(manifestFile.File))
+		for _, spec := range manifestFile.Spec {
+			if variables, err := spec.Release.Values.Variables(); err == nil && len(variables) != 0 && len(spec.Configs) != 0 {
+				requests = append(requests, config.Request{Providers: manifestProviders, Context: contextMap, Data: spec.Configs})
+			}
+			if len(spec.Mounts) != 0 {
+				requests = append(requests, config.Request{Providers: manifestProviders, Context: contextMap, Data: spec.Mounts})
+			}
+		}
+	}
+	config.Prefetch(requests)
+}
+
 // HasBuildStep returns whether the chart has to be built for the releaseSpecification
 func (manifestFiles ManifestFiles) HasBuildStep() bool {
 	for _, manifestFile := range manifestFiles {
@@ -1,8 +1,10 @@
 package stevedore
 
 import (
+	"sync"
 	"testing"
 
+	"github.com/gojek/stevedore/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -388,3 +390,43 @@ func TestManifestFilesHasBuildStep(t *testing.T) {
 		assert.False(t, ok)
 	})
 }
+
+// countingProvider returns the same configs for any data, counting the fetches
+type countingProvider struct {
+	mutex sync.Mutex
+	calls int
+}
+
+func (provider *countingProvider) Fetch(map[string]string, interface{}) (map[string]interface{}, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	provider.calls++
+	return map[string]interface{}{"NAME": "x-service"}, nil
+}
+
+func TestManifestFilesEnrichFetchesOnce(t *testing.T) {
+	t.Run("should fetch the same configs once for the manifests in different directories", func(t *testing.T) {
+		context := Context{Name: "staging", Environment: "staging", EnvironmentType: "staging"}
+		manifest := Manifest{
+			DeployTo: Matchers{{ConditionContextName: "staging"}},
+			Spec: ReleaseSpecifications{{
+				Release: Release{Name: "x-service", Values: Values{"name": "${NAME}"}},
+				Configs: Configs{"store": map[string]interface{}{"name": "x-service"}},
+			}},
+		}
+		manifestFiles := ManifestFiles{
+			{File: "/mock/x/x-stevedore.yaml", Manifest: manifest},
+			{File: "/mock/y/y-stevedore.yaml", Manifest: manifest},
+		}
+		provider := &countingProvider{}
+		providers := config.Providers{{Name: "store", Provider: provider}}.WithCache(config.NewCache(2, nil))
+
+		enriched, _, err := manifestFiles.Enrich(Overrides{}, context, Substitute{}, Ignores{}, providers)
+
+		assert.NoError(t, err)
+		if assert.Len(t, enriched, 2) {
+			assert.Equal(t, Values{"name": "x-service"}, enriched[1].Spec[0].Release.Values)
+		}
+		assert.Equal(t, 1, provider.calls)
+	})
+}
@@ -395,8 +395,9 @@ func TestManifestEnrichWith(t *testing.T) {
 			},
 		}
 
-		actual := manifest.EnrichWith(stevedore.Context{EnvironmentType: "staging", Environment: "some-specific-staging-env"}, overrides)
+		actual, err := manifest.EnrichWith(stevedore.Context{EnvironmentType: "staging", Environment: "some-specific-staging-env"}, overrides)
 
+		assert.NoError(t, err)
 		assert.Equal(t, expected, actual)
 	})
 }
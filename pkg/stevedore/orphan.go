@@ -0,0 +1,80 @@
+package stevedore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gojek/stevedore/pkg/helm"
+)
+
+// OrphanedRelease represents a release managed by stevedore,
+// which is not declared by any of the applicable manifests
+type OrphanedRelease struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+}
+
+// OrphansFile is the file of the responses of pruning the orphaned releases, which are not declared by any manifest
+const OrphansFile = "orphaned releases"
+
+// OrphanedReleases is a collection of OrphanedRelease
+type OrphanedReleases []OrphanedRelease
+
+// Applicable returns the manifests which are applicable for the given context
+func (manifestFiles ManifestFiles) Applicable(context Context) Manifests {
+	applicableManifestFiles, _ := manifestFiles.applicableFor(context)
+	manifests := Manifests{}
+	for _, manifestFile := range applicableManifestFiles {
+		manifests = append(manifests, manifestFile.Manifest)
+	}
+	return manifests
+}
+
+// FindOrphans returns the releases managed by stevedore in the namespaces of the manifests,
+// which are not declared by any of the manifests
+func FindOrphans(ctx context.Context, client helm.Client, manifests Manifests) (OrphanedReleases, error) {
+	declared := map[OrphanedRelease]bool{}
+	for _, manifest := range manifests {
+		for _, releaseSpecification := range manifest.Spec {
+			declared[OrphanedRelease{Name: releaseSpecification.Release.Name, Namespace: releaseSpecification.Release.Namespace}] = true
+		}
+	}
+
+	orphans := OrphanedReleases{}
+
+	namespaces := manifests.Namespaces()
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		releaseNames, err := client.ManagedReleases(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(releaseNames)
+		for _, releaseName := range releaseNames {
+			if release := (OrphanedRelease{Name: releaseName, Namespace: namespace}); !declared[release] {
+				orphans = append(orphans, release)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// Prune uninstalls the orphaned releases one after another
+func (orphans OrphanedReleases) Prune(ctx context.Context, client helm.Client, helmTimeout int64) Responses {
+	var responses Responses
+	for _, orphan := range orphans {
+		uninstallResponse, err := client.Uninstall(ctx, orphan.Name, orphan.Namespace, false, helmTimeout)
+		if err != nil {
+			err = releaseError("uninstalling", orphan.Name, err)
+		}
+		responses = append(responses, Response{
+			File:                  OrphansFile,
+			ReleaseName:           orphan.Name,
+			ChartVersion:          uninstallResponse.ChartVersion,
+			CurrentReleaseVersion: uninstallResponse.CurrentReleaseVersion,
+			UpstallResponse:       uninstallResponse,
+			Err:                   err,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,91 @@
+package stevedore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	mocks "github.com/gojek/stevedore/pkg/internal/mocks/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOrphans(t *testing.T) {
+	manifests := stevedore.Manifests{
+		{Spec: stevedore.ReleaseSpecifications{
+			{Release: stevedore.Release{Name: "app", Namespace: "default"}},
+			{Release: stevedore.Release{Name: "db", Namespace: "infra"}},
+		}},
+		{Spec: stevedore.ReleaseSpecifications{
+			{Release: stevedore.Release{Name: "worker", Namespace: "default"}},
+		}},
+	}
+
+	t.Run("should return managed releases which are not declared in the manifests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		client.EXPECT().ManagedReleases(context.TODO(), "default").Return([]string{"worker", "old-app", "app"}, nil)
+		client.EXPECT().ManagedReleases(context.TODO(), "infra").Return([]string{"db", "app"}, nil)
+
+		orphans, err := stevedore.FindOrphans(context.TODO(), client, manifests)
+
+		assert.NoError(t, err)
+		expected := stevedore.OrphanedReleases{
+			{Name: "old-app", Namespace: "default"},
+			{Name: "app", Namespace: "infra"},
+		}
+		assert.Equal(t, expected, orphans)
+	})
+
+	t.Run("should return error when unable to list releases", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		client.EXPECT().ManagedReleases(context.TODO(), "default").Return(nil, fmt.Errorf("unreachable"))
+
+		orphans, err := stevedore.FindOrphans(context.TODO(), client, manifests)
+
+		assert.Nil(t, orphans)
+		assert.EqualError(t, err, "unreachable")
+	})
+}
+
+func TestOrphanedReleasesPrune(t *testing.T) {
+	t.Run("should uninstall all the orphaned releases", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		var timeout int64 = 10
+		client.EXPECT().Uninstall(context.TODO(), "old-app", "default", false, timeout).Return(helm.UpstallResponse{HasDiff: true, CurrentReleaseVersion: 3}, nil)
+		client.EXPECT().Uninstall(context.TODO(), "app", "infra", false, timeout).Return(helm.UpstallResponse{}, fmt.Errorf("timed out"))
+
+		orphans := stevedore.OrphanedReleases{
+			{Name: "old-app", Namespace: "default"},
+			{Name: "app", Namespace: "infra"},
+		}
+		responses := orphans.Prune(context.TODO(), client, timeout)
+
+		expected := stevedore.Responses{
+			{File: stevedore.OrphansFile, ReleaseName: "old-app", CurrentReleaseVersion: 3, UpstallResponse: helm.UpstallResponse{HasDiff: true, CurrentReleaseVersion: 3}},
+			{File: stevedore.OrphansFile, ReleaseName: "app", Err: fmt.Errorf("error when uninstalling app due to timed out")},
+		}
+		assert.Equal(t, expected, responses)
+	})
+}
+
+func TestManifestFilesApplicable(t *testing.T) {
+	t.Run("should return manifests applicable for the context", func(t *testing.T) {
+		applicable := stevedore.Manifest{DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "services"}}}
+		manifestFiles := stevedore.ManifestFiles{
+			{File: "a.yaml", Manifest: applicable},
+			{File: "b.yaml", Manifest: stevedore.Manifest{DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "components"}}}},
+		}
+
+		manifests := manifestFiles.Applicable(stevedore.Context{Name: "services"})
+
+		assert.Equal(t, stevedore.Manifests{applicable}, manifests)
+	})
+}
@@ -1,5 +1,7 @@
 package stevedore
 
+import "github.com/gojek/stevedore/pkg/merger"
+
 // OverrideSpecification represents a single override
 type OverrideSpecification struct {
 	FileName string     `yaml:"-" json:"-"`
@@ -9,9 +11,12 @@ type OverrideSpecification struct {
 
 // IsValid validates the context and returns error if any
 func (spec OverrideSpecification) IsValid() error {
-	return validate.Struct(spec)
+	if err := validate.Struct(spec); err != nil {
+		return err
+	}
+	return merger.Validate(spec.Values)
 }
 
-func (spec OverrideSpecification) weight() int {
-	return spec.Matches.Weight()
+func (spec OverrideSpecification) weight(weights Weights) int {
+	return spec.Matches.Weight(weights)
 }
@@ -20,29 +20,29 @@ func (specs OverrideSpecifications) filterBy(predicate Predicate) OverrideSpecif
 	return matchedOverrides
 }
 
-// Sort returns Overrides based on pre determined order
-func (specs OverrideSpecifications) sort() {
+// Sort returns Overrides based on the weights of their conditions
+func (specs OverrideSpecifications) sort(weights Weights) {
 	sort.SliceStable(specs, func(i, j int) bool {
-		return specs[i].weight() < specs[j].weight()
+		return specs[i].weight(weights) < specs[j].weight(weights)
 	})
 }
 
 // CollateBy filters overrides by predicate and sort it by its weight
 func (specs OverrideSpecifications) CollateBy(predicate Predicate) OverrideSpecifications {
 	filteredOverrides := specs.filterBy(predicate)
-	filteredOverrides.sort()
+	filteredOverrides.sort(predicate.weights)
 	return filteredOverrides
 }
 
 // MergeValuesInto merges the values from overrides into the base values
-func (specs OverrideSpecifications) MergeValuesInto(base Values) Values {
+func (specs OverrideSpecifications) MergeValuesInto(base Values) (Values, error) {
 	values := []map[string]interface{}{base}
 	for _, override := range specs {
 		values = append(values, override.Values)
 	}
 	result, err := merger.Merge(values...)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return result
+	return result, nil
 }
@@ -176,10 +176,58 @@ func TestOverrideSpecificationsMergeValuesInto(t *testing.T) {
 			"key3": "baseValueForK3",
 		}
 
-		actual := overrideSpecifications.MergeValuesInto(baseValues)
+		actual, err := overrideSpecifications.MergeValuesInto(baseValues)
 
+		assert.NoError(t, err)
 		if !reflect.DeepEqual(actual, expected) {
 			t.Errorf("Actual: %#v did not match \nExpected: %#v", actual, expected)
 		}
 	})
+
+	t.Run("should merge lists using merge directives", func(t *testing.T) {
+		baseValues := stevedore.Values{
+			"env": []interface{}{
+				map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "info"},
+				map[interface{}]interface{}{"name": "PORT", "value": 8080},
+			},
+			"annotations": map[interface{}]interface{}{"team": "platform"},
+		}
+
+		overrideSpecifications := stevedore.OverrideSpecifications{
+			{Values: stevedore.Values{"env": map[interface{}]interface{}{
+				"$mergeBy": "name",
+				"$items": []interface{}{
+					map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+					map[interface{}]interface{}{"name": "DEBUG", "value": true},
+				},
+			}}},
+			{Values: stevedore.Values{"annotations": map[interface{}]interface{}{"$delete": true}}},
+		}
+
+		expected := stevedore.Values{
+			"env": []interface{}{
+				map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+				map[interface{}]interface{}{"name": "PORT", "value": 8080},
+				map[interface{}]interface{}{"name": "DEBUG", "value": true},
+			},
+		}
+
+		actual, err := overrideSpecifications.MergeValuesInto(baseValues)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, "info", baseValues["env"].([]interface{})[0].(map[interface{}]interface{})["value"])
+	})
+	t.Run("should return error for merge directives at root", func(t *testing.T) {
+		baseValues := stevedore.Values{"name": "x-service"}
+		overrideSpecifications := stevedore.OverrideSpecifications{
+			{Values: stevedore.Values{"$replace": map[interface{}]interface{}{"name": "y-service"}}},
+		}
+
+		_, err := overrideSpecifications.MergeValuesInto(baseValues)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at root: directives can not be used at root", err.Error())
+		}
+	})
 }
@@ -13,6 +13,7 @@ func TestOverrideSpecificationIsValid(t *testing.T) {
 		name       string
 		valid      bool
 		conditions stevedore.Conditions
+		values     stevedore.Values
 		error      string
 	}
 
@@ -24,12 +25,15 @@ func TestOverrideSpecificationIsValid(t *testing.T) {
 		{name: "should be valid for contextName", valid: true, conditions: stevedore.Conditions{"contextName": "components"}},
 		{name: "should be valid for applicationName", valid: true, conditions: stevedore.Conditions{"applicationName": "x-service"}},
 		{name: "should be invalid for unknown", valid: false, conditions: stevedore.Conditions{"unknown": "invalid"}, error: "Key: 'OverrideSpecification.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
+		{name: "should be valid for merge directives", valid: true, conditions: stevedore.Conditions{}, values: stevedore.Values{"env": map[interface{}]interface{}{"$append": []interface{}{"item"}}}},
+		{name: "should be invalid for invalid merge directives", valid: false, conditions: stevedore.Conditions{}, values: stevedore.Values{"env": map[interface{}]interface{}{"$append": "item"}}, error: "invalid merge directive at env: $append requires a list"},
+		{name: "should be invalid for merge directives at root", valid: false, conditions: stevedore.Conditions{}, values: stevedore.Values{"$delete": true}, error: "invalid merge directive at root: directives can not be used at root"},
 		{name: "should be invalid even if one field is not valid", valid: false, conditions: stevedore.Conditions{"applicationName": "x-service", "unknown": "invalid"}, error: "Key: 'OverrideSpecification.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
 	}
 
 	for _, scenario := range scenarios {
 		t.Run(fmt.Sprintf("%v", scenario.name), func(t *testing.T) {
-			override := stevedore.OverrideSpecification{Matches: scenario.conditions}
+			override := stevedore.OverrideSpecification{Matches: scenario.conditions, Values: scenario.values}
 
 			err := override.IsValid()
 
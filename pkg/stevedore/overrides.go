@@ -32,8 +32,13 @@ func (overrides Overrides) Format(f fmt.State, c rune) {
 
 // NewOverrides to Validate the Stevedore manifest configuration
 func NewOverrides(reader io.Reader) (Overrides, error) {
+	reader, err := converters.convert(reader)
+	if err != nil {
+		return Overrides{}, err
+	}
+
 	overrides := Overrides{}
-	err := yaml.NewDecoder(reader).Decode(&overrides)
+	err = yaml.NewDecoder(reader).Decode(&overrides)
 	if err != nil {
 		return Overrides{}, fmt.Errorf("[NewOverrides] error when validating from file:\n%v", err)
 	}
@@ -58,6 +63,6 @@ func (overrides Overrides) CollateBy(predicate Predicate) Overrides {
 }
 
 // MergeValuesInto merges the values from overrides into the base values
-func (overrides Overrides) MergeValuesInto(base Values) Values {
+func (overrides Overrides) MergeValuesInto(base Values) (Values, error) {
 	return overrides.Spec.MergeValuesInto(base)
 }
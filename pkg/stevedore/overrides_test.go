@@ -11,6 +11,26 @@ import (
 )
 
 func TestNewOverrides(t *testing.T) {
+	t.Run("should create overrides with set and negated conditions", func(t *testing.T) {
+		overrideString := `
+kind: StevedoreOverride
+version: 2
+spec:
+- matches:
+    environmentType: [staging, production]
+    contextName: "!prod-*"
+  values:
+    replicas: 2`
+
+		actual, err := stevedore.NewOverrides(strings.NewReader(overrideString))
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Conditions{
+			"environmentType": []interface{}{"staging", "production"},
+			"contextName":     "!prod-*",
+		}, actual.Spec[0].Matches)
+	})
+
 	t.Run("should create overrides", func(t *testing.T) {
 		overrideString := `
 kind: StevedoreOverride
@@ -2,20 +2,24 @@ package stevedore
 
 // Predicate represents the set of condition to match
 type Predicate struct {
-	conditions Conditions
+	conditions map[string]string
+	weights    Weights
 }
 
 func (predicate Predicate) add(key, value string) {
 	predicate.conditions[key] = value
 }
 
-// Contains checks if all the given conditions are present in the predicate
+// Contains checks if all the given conditions match the predicate.
+// A condition on a key which the predicate does not have, such as a label which the context does not have,
+// does not match even if it is negated
 func (predicate Predicate) Contains(conditions Conditions) bool {
 	if len(conditions) == 0 {
 		return false
 	}
-	for key, value := range conditions {
-		if predicate.conditions[key] != value {
+	for key := range conditions {
+		value, ok := predicate.conditions[key]
+		if !ok || !conditions.Matches(key, value) {
 			return false
 		}
 	}
@@ -31,10 +35,13 @@ func NewPredicate(releaseSpecification ReleaseSpecification, context Context) Pr
 
 // NewPredicateFromContext returns a predicate based on context
 func NewPredicateFromContext(context Context) Predicate {
-	predicate := Predicate{conditions: Conditions{}}
+	predicate := Predicate{conditions: map[string]string{}, weights: context.ConditionWeights()}
 	predicate.add(ConditionEnvironment, context.Environment)
 	predicate.add(ConditionEnvironmentType, context.EnvironmentType)
 	predicate.add(ConditionContextName, context.Name)
 	predicate.add(ConditionContextType, context.Type)
+	for key, value := range context.Labels {
+		predicate.add(LabelCondition(key), value)
+	}
 	return predicate
 }
@@ -31,6 +31,7 @@ func TestNewPredicate(t *testing.T) {
 				"contextType":     "components",
 				"applicationName": "x-stevedore",
 			},
+			weights: defaultConditionWeights,
 		}
 		predicate := NewPredicate(app, ctx)
 
@@ -56,12 +57,23 @@ func TestNewPredicateFromContext(t *testing.T) {
 				"contextName":     "components",
 				"contextType":     "components",
 			},
+			weights: defaultConditionWeights,
 		}
 		predicate := NewPredicateFromContext(ctx)
 
 		assert.NotNil(t, predicate)
 		assert.Equal(t, expectedPredicate, predicate)
 	})
+
+	t.Run("should create predicate with the labels of the context", func(t *testing.T) {
+		ctx := Context{Name: "components", Type: "components", EnvironmentType: "staging", Environment: "staging", Labels: Labels{"region": "eu-west"}}
+
+		predicate := NewPredicateFromContext(ctx)
+
+		assert.True(t, predicate.Contains(Conditions{"labels.region": "eu-west", "environmentType": "staging"}))
+		assert.False(t, predicate.Contains(Conditions{"labels.region": "us-east"}))
+		assert.False(t, predicate.Contains(Conditions{"labels.team": "payments"}))
+	})
 }
 
 func TestPredicateContains(t *testing.T) {
@@ -84,7 +96,7 @@ func TestPredicateContains(t *testing.T) {
 	predicate := NewPredicate(app, ctx)
 
 	t.Run("should return true if given conditions is a subset of the predicate", func(t *testing.T) {
-		conditions := map[string]string{
+		conditions := Conditions{
 			"contextName": "components",
 		}
 
@@ -92,7 +104,7 @@ func TestPredicateContains(t *testing.T) {
 	})
 
 	t.Run("should return false if given conditions is not a subset of the predicate", func(t *testing.T) {
-		conditions := map[string]string{
+		conditions := Conditions{
 			"contextName":     "components",
 			"contextType":     "components",
 			"environmentType": "production",
@@ -102,7 +114,7 @@ func TestPredicateContains(t *testing.T) {
 	})
 
 	t.Run("should return false if given conditions is empty", func(t *testing.T) {
-		conditions := map[string]string{}
+		conditions := Conditions{}
 
 		assert.False(t, predicate.Contains(conditions))
 	})
@@ -110,4 +122,23 @@ func TestPredicateContains(t *testing.T) {
 	t.Run("should return false if given conditions is nil", func(t *testing.T) {
 		assert.False(t, predicate.Contains(nil))
 	})
+
+	t.Run("should match set, negated, glob and regex conditions", func(t *testing.T) {
+		assert.True(t, predicate.Contains(Conditions{"environmentType": []interface{}{"staging", "production"}}))
+		assert.False(t, predicate.Contains(Conditions{"environmentType": []interface{}{"integration", "production"}}))
+		assert.True(t, predicate.Contains(Conditions{"contextName": "!prod-*"}))
+		assert.False(t, predicate.Contains(Conditions{"contextName": "!comp*"}))
+		assert.True(t, predicate.Contains(Conditions{"contextName": "comp*", "applicationName": "x-*"}))
+		assert.True(t, predicate.Contains(Conditions{"contextName": "/^comp[a-z]+s$/"}))
+		assert.False(t, predicate.Contains(Conditions{"contextName": "/comp/"}))
+		assert.True(t, predicate.Contains(Conditions{"contextName": []interface{}{"comp*", "!components-legacy"}}))
+		assert.False(t, predicate.Contains(Conditions{"contextName": []interface{}{"comp*", "!components"}}))
+		assert.True(t, predicate.Contains(Conditions{"contextName": []interface{}{"!prod", "!production"}}))
+	})
+
+	t.Run("should not match conditions on missing labels even if negated", func(t *testing.T) {
+		assert.False(t, predicate.Contains(Conditions{"labels.region": "!eu-*"}))
+		assert.False(t, predicate.Contains(Conditions{"labels.region": []interface{}{"!eu-west"}}))
+		assert.False(t, predicate.Contains(Conditions{"contextName": "comp*", "labels.region": "!eu-*"}))
+	})
 }
@@ -0,0 +1,271 @@
+package stevedore
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gojek/stevedore/pkg/merger"
+)
+
+// Types of the sources of values
+const (
+	ManifestSource    = "manifest"
+	OverrideSource    = "override"
+	MountSource       = "mount"
+	EnvSource         = "env"
+	EnvironmentSource = "environment"
+	ConfigSource      = "config"
+	DefaultSource     = "default"
+)
+
+// Source represents where a value or a variable came from
+type Source struct {
+	Type     string     `json:"type" yaml:"type"`
+	File     string     `json:"file,omitempty" yaml:"file,omitempty"`
+	Matches  Conditions `json:"matches,omitempty" yaml:"matches,omitempty"`
+	Weight   int        `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Provider string     `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Variable string     `json:"variable,omitempty" yaml:"variable,omitempty"`
+}
+
+// Sources represents the source of each variable by its name
+type Sources map[string]Source
+
+// Origin represents the source of a value along with the sources of the variables substituted in it
+type Origin struct {
+	Source    `json:",inline" yaml:",inline"`
+	Variables []Source `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// Provenance represents the origin of each leaf of the values by its path
+type Provenance map[string]Origin
+
+// Explanation represents a leaf of the values along with its origin
+type Explanation struct {
+	Path   string      `json:"path" yaml:"path"`
+	Value  interface{} `json:"value" yaml:"value"`
+	Origin `json:",inline" yaml:",inline"`
+}
+
+// Explanations is a collection of Explanation
+type Explanations []Explanation
+
+func newProvenance(values Values, source Source) Provenance {
+	provenance := Provenance{}
+	for path := range leaves(values) {
+		provenance[path] = Origin{Source: source}
+	}
+	return provenance
+}
+
+// track attributes the leaves of after, which are set by the layer or differ from before, to the source.
+// Rest of the leaves retain their origin
+func (provenance Provenance) track(before, after, layer map[string]interface{}, source Source) Provenance {
+	if provenance == nil {
+		return nil
+	}
+
+	beforeLeaves := leaves(before)
+	layerLeaves := leaves(layer)
+	result := Provenance{}
+	for path, value := range leaves(after) {
+		origin, tracked := provenance[path]
+		previous, existed := beforeLeaves[path]
+		_, overridden := layerLeaves[path]
+		if tracked && existed && !overridden && reflect.DeepEqual(previous, value) {
+			result[path] = origin
+			continue
+		}
+		result[path] = Origin{Source: source}
+	}
+	return result
+}
+
+// override tracks the values merged from each of the overrides in order,
+// and returns error if any of the overrides can not be merged
+func (provenance Provenance) override(base Values, specs OverrideSpecifications, weights Weights) (Provenance, error) {
+	if provenance == nil {
+		return nil, nil
+	}
+
+	values := map[string]interface{}(base)
+	for _, spec := range specs {
+		merged, err := merger.Merge(values, spec.Values)
+		if err != nil {
+			return nil, err
+		}
+		source := Source{Type: OverrideSource, File: spec.FileName, Matches: spec.Matches, Weight: spec.weight(weights)}
+		provenance = provenance.track(values, merged, spec.Values, source)
+		values = merged
+	}
+	return provenance, nil
+}
+
+// substitute retains the origin of the leaves which had placeholders and adds the sources of their variables
+func (provenance Provenance) substitute(before, after Values, sourceOf func(placeholder) Source) Provenance {
+	if provenance == nil {
+		return nil
+	}
+
+	beforeLeaves := leaves(before)
+	result := Provenance{}
+	for path := range leaves(after) {
+		originalPath, ok := ancestorIn(beforeLeaves, path)
+		if !ok {
+			result[path] = provenance[path]
+			continue
+		}
+
+		origin := provenance[originalPath]
+		origin.Variables = append([]Source(nil), origin.Variables...)
+		if str, ok := beforeLeaves[originalPath].(string); ok {
+			for _, matchGroups := range placeholderPattern.FindAllStringSubmatch(str, -1) {
+				if placeholder, ok := parsePlaceholder(matchGroups[1]); ok {
+					origin.Variables = append(origin.Variables, sourceOf(placeholder))
+				}
+			}
+		}
+		result[path] = origin
+	}
+	return result
+}
+
+// Explain returns the explanations of the values sorted by path,
+// with the sources of the variables replaced by the given sources
+func (provenance Provenance) Explain(values Values, variables Sources) Explanations {
+	var result Explanations
+	for path, value := range leaves(values) {
+		origin := provenance[path]
+		resolved := make([]Source, 0, len(origin.Variables))
+		for _, variable := range origin.Variables {
+			if source, ok := variables[variable.Variable]; ok && variable.Type == EnvSource {
+				variable = source
+			}
+			resolved = append(resolved, variable)
+		}
+		if len(resolved) != 0 {
+			origin.Variables = resolved
+		}
+		result = append(result, Explanation{Path: path, Value: value, Origin: origin})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// SourceOf returns the source of the value at the given path,
+// which is either a leaf, or a map or list whose first leaf by path is used
+func (provenance Provenance) SourceOf(path string) (Source, bool) {
+	if origin, ok := provenance[path]; ok {
+		return origin.Source, true
+	}
+	if ancestor, ok := ancestorIn(provenance.paths(), path); ok {
+		return provenance[ancestor].Source, true
+	}
+
+	var descendants []string
+	for each := range provenance {
+		if strings.HasPrefix(each, path+".") || strings.HasPrefix(each, path+"[") {
+			descendants = append(descendants, each)
+		}
+	}
+	if len(descendants) == 0 {
+		return Source{}, false
+	}
+	sort.Strings(descendants)
+	return provenance[descendants[0]].Source, true
+}
+
+func (provenance Provenance) paths() map[string]interface{} {
+	result := make(map[string]interface{}, len(provenance))
+	for path := range provenance {
+		result[path] = nil
+	}
+	return result
+}
+
+// String returns the human readable description of the source
+func (source Source) String() string {
+	var details []string
+	if source.Variable != "" {
+		details = append(details, fmt.Sprintf("variable %s", source.Variable))
+	}
+	if source.File != "" {
+		details = append(details, source.File)
+	}
+	if source.Provider != "" {
+		details = append(details, fmt.Sprintf("provider %s", source.Provider))
+	}
+	if len(source.Matches) != 0 {
+		var matches []string
+		for criteria, condition := range source.Matches {
+			matches = append(matches, fmt.Sprintf("%s=%v", criteria, condition))
+		}
+		sort.Strings(matches)
+		details = append(details, fmt.Sprintf("matches %s", strings.Join(matches, " ")))
+	}
+	if source.Type == OverrideSource || (source.Type == EnvSource && source.File != "") {
+		details = append(details, fmt.Sprintf("weight %d", source.Weight))
+	}
+
+	if len(details) == 0 {
+		return source.Type
+	}
+	return fmt.Sprintf("%s (%s)", source.Type, strings.Join(details, ", "))
+}
+
+// ancestorIn returns the path or its nearest ancestor present in the given leaves
+func ancestorIn(leaves map[string]interface{}, path string) (string, bool) {
+	for path != "" {
+		if _, ok := leaves[path]; ok {
+			return path, true
+		}
+		index := strings.LastIndexAny(path, ".[")
+		if index < 0 {
+			return "", false
+		}
+		path = path[:index]
+	}
+	return "", false
+}
+
+// leaves returns the leaves of the value by their path, such as a.b[0].c
+func leaves(value interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	collectLeaves(value, "", result)
+	return result
+}
+
+func collectLeaves(value interface{}, path string, result map[string]interface{}) {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Map:
+		if reflected.Len() == 0 && path != "" {
+			result[path] = value
+			return
+		}
+		for _, key := range reflected.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			if path != "" {
+				name = fmt.Sprintf("%s.%s", path, name)
+			}
+			collectLeaves(reflected.MapIndex(key).Interface(), name, result)
+		}
+	case reflect.Slice:
+		if reflected.Len() == 0 {
+			result[path] = value
+			return
+		}
+		for index := 0; index < reflected.Len(); index++ {
+			collectLeaves(reflected.Index(index).Interface(), fmt.Sprintf("%s[%d]", path, index), result)
+		}
+	default:
+		if path != "" {
+			result[path] = value
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package stevedore_test
+
+import (
+	"testing"
+
+	"github.com/gojek/stevedore/client/provider"
+	"github.com/gojek/stevedore/pkg/config"
+	mockPlugin "github.com/gojek/stevedore/pkg/internal/mocks/plugin"
+	pkgPlugin "github.com/gojek/stevedore/pkg/plugin"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestFilesTrackProvenance(t *testing.T) {
+	context := stevedore.Context{Name: "components", Environment: "staging", EnvironmentType: "staging", Type: "components"}
+	manifestFiles := func(values stevedore.Values, mounts stevedore.Configs) stevedore.ManifestFiles {
+		return stevedore.ManifestFiles{
+			{
+				File: "/mock/x-stevedore.yaml",
+				Manifest: stevedore.Manifest{
+					DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "components"}},
+					Spec: stevedore.ReleaseSpecifications{
+						{
+							Release: stevedore.Release{Name: "x-service", Namespace: "default", Chart: "chart/x-service", Values: values},
+							Mounts:  mounts,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("should explain the source of each value", func(t *testing.T) {
+		overrides := stevedore.Overrides{Spec: stevedore.OverrideSpecifications{
+			{
+				FileName: "/mock/overrides.yaml",
+				Matches:  stevedore.Conditions{stevedore.ConditionEnvironment: "staging"},
+				Values:   stevedore.Values{"replicas": 2, "env": map[interface{}]interface{}{"$append": []interface{}{"DEBUG"}}},
+			},
+		}}
+		values := stevedore.Values{
+			"replicas": 1,
+			"name":     "x-service",
+			"env":      []interface{}{"LOG_LEVEL"},
+			"url":      "http://${HOST}:${PORT:-8080}",
+		}
+
+		enriched, _, err := manifestFiles(values, nil).TrackProvenance().Enrich(overrides, context, stevedore.Substitute{"HOST": "localhost"}, stevedore.Ignores{}, config.Providers{})
+
+		assert.NoError(t, err)
+		manifestSource := stevedore.Source{Type: stevedore.ManifestSource, File: "/mock/x-stevedore.yaml"}
+		overrideSource := stevedore.Source{Type: stevedore.OverrideSource, File: "/mock/overrides.yaml", Matches: stevedore.Conditions{stevedore.ConditionEnvironment: "staging"}, Weight: 2}
+		envSources := stevedore.Sources{"HOST": {Type: stevedore.EnvSource, File: "/mock/env.yaml", Variable: "HOST"}}
+		expected := stevedore.Explanations{
+			{Path: "env[0]", Value: "LOG_LEVEL", Origin: stevedore.Origin{Source: manifestSource}},
+			{Path: "env[1]", Value: "DEBUG", Origin: stevedore.Origin{Source: overrideSource}},
+			{Path: "name", Value: "x-service", Origin: stevedore.Origin{Source: manifestSource}},
+			{Path: "replicas", Value: 2, Origin: stevedore.Origin{Source: overrideSource}},
+			{Path: "url", Value: "http://localhost:8080", Origin: stevedore.Origin{Source: manifestSource, Variables: []stevedore.Source{
+				{Type: stevedore.EnvSource, File: "/mock/env.yaml", Variable: "HOST"},
+				{Type: stevedore.DefaultSource, Variable: "PORT"},
+			}}},
+		}
+
+		actual := enriched[0].Spec[0].Release.Explain(envSources)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should explain the values substituted from configs and mounted from providers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storeProvider := mockPlugin.NewMockConfigInterface(ctrl)
+		storeProvider.EXPECT().Type().Return(pkgPlugin.TypeConfig, nil)
+		storeProvider.EXPECT().Fetch(gomock.Any(), gomock.Any()).Return(map[string]interface{}{"resources": map[interface{}]interface{}{"cpu": "100m"}}, nil)
+		secretsProvider := mockPlugin.NewMockConfigInterface(ctrl)
+		secretsProvider.EXPECT().Type().Return(pkgPlugin.TypeConfig, nil)
+		secretsProvider.EXPECT().Fetch(gomock.Any(), gomock.Any()).Return(map[string]interface{}{"password": "s3cr3t"}, nil)
+		plugins := provider.Plugins{"store": provider.ClientPlugin{PluginImpl: storeProvider}, "secrets": provider.ClientPlugin{PluginImpl: secretsProvider}}
+		configProviders, _ := plugins.ConfigProviders()
+
+		values := stevedore.Values{"resources": "${resources}"}
+		manifests := manifestFiles(values, stevedore.Configs{"secrets": []map[string]interface{}{}})
+		manifests[0].Spec[0].Configs = stevedore.Configs{"store": []map[string]interface{}{}}
+
+		enriched, _, err := manifests.TrackProvenance().Enrich(stevedore.Overrides{}, context, stevedore.Substitute{}, stevedore.Ignores{}, configProviders)
+
+		assert.NoError(t, err)
+		manifestSource := stevedore.Source{Type: stevedore.ManifestSource, File: "/mock/x-stevedore.yaml"}
+		expected := stevedore.Explanations{
+			{Path: "password", Value: "s3cr3t", Origin: stevedore.Origin{Source: stevedore.Source{Type: stevedore.MountSource, Provider: "secrets"}}},
+			{Path: "resources.cpu", Value: "100m", Origin: stevedore.Origin{Source: manifestSource, Variables: []stevedore.Source{
+				{Type: stevedore.ConfigSource, Provider: "store", Variable: "resources"},
+			}}},
+		}
+
+		actual := enriched[0].Spec[0].Release.Explain(nil)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should not track provenance unless asked", func(t *testing.T) {
+		enriched, _, err := manifestFiles(stevedore.Values{"name": "x-service"}, nil).Enrich(stevedore.Overrides{}, context, stevedore.Substitute{}, stevedore.Ignores{}, config.Providers{})
+
+		assert.NoError(t, err)
+		assert.Nil(t, enriched[0].Spec[0].Release.Provenance())
+	})
+}
+
+func TestSourceString(t *testing.T) {
+	t.Run("should describe the source", func(t *testing.T) {
+		override := stevedore.Source{Type: stevedore.OverrideSource, File: "/mock/overrides.yaml", Matches: stevedore.Conditions{"environment": "staging"}, Weight: 1}
+		variable := stevedore.Source{Type: stevedore.ConfigSource, Provider: "store", Variable: "NAME"}
+
+		assert.Equal(t, `override (/mock/overrides.yaml, matches environment=staging, weight 1)`, override.String())
+		assert.Equal(t, "config (variable NAME, provider store)", variable.String())
+		assert.Equal(t, "environment (variable HOME)", stevedore.Source{Type: stevedore.EnvironmentSource, Variable: "HOME"}.String())
+	})
+}
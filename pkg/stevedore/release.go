@@ -33,6 +33,7 @@ type Release struct {
 	Values         Values `json:"values" yaml:"values"`
 	usedSubstitute Substitute
 	overrides      Overrides
+	provenance     Provenance
 }
 
 // NewRelease returns a new Release
@@ -42,20 +43,30 @@ func NewRelease(name, namespace, chart, chartVersion string, chartSpec ChartSpec
 	return Release{Name: name, Namespace: namespace, Chart: chart, ChartVersion: chartVersion, ChartSpec: chartSpec, CurrentReleaseVersion: currentReleaseVersion, Values: values, usedSubstitute: usedSubstitute, overrides: overrides}
 }
 
-// EnrichValues will return enriched release with final merged values
-func (release Release) EnrichValues(overrides Overrides) Release {
-	result := release.Values.MergeWith(overrides)
+// EnrichValues will return enriched release with final merged values,
+// using the weights of the conditions to explain the overrides
+func (release Release) EnrichValues(overrides Overrides, weights Weights) (Release, error) {
+	result, err := release.Values.MergeWith(overrides)
+	if err != nil {
+		return release, err
+	}
 	usedSubstitute := release.usedSubstitute
 	if usedSubstitute == nil {
 		usedSubstitute = Substitute{}
 	}
 
+	provenance, err := release.provenance.override(release.Values, overrides.Spec, weights)
+	if err != nil {
+		return release, err
+	}
+
+	release.provenance = provenance
 	release.Values = result
 	release.overrides = overrides
 	release.usedSubstitute = usedSubstitute
 
 	// This is pass by value and it is a copy. Its not a mutation.
-	return release
+	return release, nil
 }
 
 // Replace will return release with substituted values
@@ -72,6 +83,18 @@ func (release Release) Replace(with Substitute) (Release, error) {
 	return release, nil
 }
 
+// Provenance returns the origin of each leaf of the values.
+// It is nil unless the provenance is tracked
+func (release Release) Provenance() Provenance {
+	return release.provenance
+}
+
+// Explain returns the origin of each leaf of the values.
+// The sources of the variables are resolved using the given sources when present
+func (release Release) Explain(variables Sources) Explanations {
+	return release.provenance.Explain(release.Values, variables)
+}
+
 // Overrides returns values enriched by overrides
 func (release Release) Overrides() Overrides {
 	return release.overrides
@@ -0,0 +1,190 @@
+package stevedore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// ReleaseNode represents a release specification along with
+// the manifest file in which it is declared
+type ReleaseNode struct {
+	File string
+	ReleaseSpecification
+}
+
+// String returns the release name along with its file name
+func (node ReleaseNode) String() string {
+	return fmt.Sprintf("%s (%s)", node.Release.Name, node.File)
+}
+
+// ReleaseNodes is a collection of ReleaseNode
+type ReleaseNodes []ReleaseNode
+
+// ReleaseGraph represents the dependsOn relationship between
+// the releases declared across all the manifest files
+type ReleaseGraph struct {
+	Nodes        ReleaseNodes
+	dependencies [][]int
+	dependents   [][]int
+}
+
+// DependencyCycleError represents a cycle in dependsOn of releases
+type DependencyCycleError struct {
+	Cycle ReleaseNodes
+}
+
+// Error returns the releases forming the cycle along with their file names
+func (err DependencyCycleError) Error() string {
+	buff := bytes.NewBufferString("cycle detected in dependsOn: ")
+	for index, node := range err.Cycle {
+		if index != 0 {
+			buff.WriteString(" -> ")
+		}
+		buff.WriteString(node.String())
+	}
+	return buff.String()
+}
+
+// DependencyFailedError represents a release which is skipped
+// as one or more of the releases it depends on have failed
+type DependencyFailedError struct {
+	ReleaseName  string
+	Dependencies []string
+}
+
+// Error returns the reason for skipping the release
+func (err DependencyFailedError) Error() string {
+	return fmt.Sprintf("skipped %s as the release(s) it depends on failed: %v", err.ReleaseName, err.Dependencies)
+}
+
+// NewReleaseGraph builds the graph from dependsOn of all the release specifications
+// in the given manifest files and returns DependencyCycleError if there is any cycle.
+//
+// dependsOn referring to a release which is not part of the given manifest files is ignored,
+// as it is not managed as part of this run
+func NewReleaseGraph(manifestFiles ManifestFiles) (ReleaseGraph, error) {
+	graph := ReleaseGraph{Nodes: ReleaseNodes{}}
+	indices := map[string][]int{}
+
+	for _, manifestFile := range manifestFiles {
+		for _, releaseSpecification := range manifestFile.Spec {
+			name := releaseSpecification.Release.Name
+			indices[name] = append(indices[name], len(graph.Nodes))
+			graph.Nodes = append(graph.Nodes, ReleaseNode{File: manifestFile.File, ReleaseSpecification: releaseSpecification})
+		}
+	}
+
+	graph.dependencies = make([][]int, len(graph.Nodes))
+	graph.dependents = make([][]int, len(graph.Nodes))
+	for index, node := range graph.Nodes {
+		added := map[int]bool{}
+		for _, dependency := range node.DependsOn {
+			for _, dependencyIndex := range indices[dependency] {
+				if added[dependencyIndex] {
+					continue
+				}
+				added[dependencyIndex] = true
+				graph.dependencies[index] = append(graph.dependencies[index], dependencyIndex)
+				graph.dependents[dependencyIndex] = append(graph.dependents[dependencyIndex], index)
+			}
+		}
+		sort.Ints(graph.dependencies[index])
+	}
+
+	if cycle := graph.cycle(); len(cycle) != 0 {
+		return ReleaseGraph{}, DependencyCycleError{Cycle: cycle}
+	}
+	return graph, nil
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+func (graph ReleaseGraph) cycle() ReleaseNodes {
+	state := make([]int, len(graph.Nodes))
+	var path []int
+
+	var visit func(index int) []int
+	visit = func(index int) []int {
+		state[index] = visiting
+		path = append(path, index)
+		for _, dependency := range graph.dependencies[index] {
+			switch state[dependency] {
+			case visiting:
+				for position, node := range path {
+					if node == dependency {
+						return append(append([]int{}, path[position:]...), dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[index] = visited
+		return nil
+	}
+
+	for index := range graph.Nodes {
+		if state[index] != unvisited {
+			continue
+		}
+		if cycle := visit(index); cycle != nil {
+			result := ReleaseNodes{}
+			for _, node := range cycle {
+				result = append(result, graph.Nodes[node])
+			}
+			return result
+		}
+	}
+	return nil
+}
+
+// Sorted returns the releases such that every release comes after the releases it depends on.
+// Releases which are independent of each other retain the order in which they are declared
+func (graph ReleaseGraph) Sorted() ReleaseNodes {
+	result := ReleaseNodes{}
+	for _, index := range graph.sorted() {
+		result = append(result, graph.Nodes[index])
+	}
+	return result
+}
+
+func (graph ReleaseGraph) sorted() []int {
+	pending := make([]int, len(graph.Nodes))
+	var ready []int
+	for index := range graph.Nodes {
+		pending[index] = len(graph.dependencies[index])
+		if pending[index] == 0 {
+			ready = append(ready, index)
+		}
+	}
+
+	var result []int
+	for len(ready) != 0 {
+		index := ready[0]
+		ready = ready[1:]
+		result = append(result, index)
+		for _, dependent := range graph.dependents[index] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = insertSorted(ready, dependent)
+			}
+		}
+	}
+	return result
+}
+
+func insertSorted(items []int, item int) []int {
+	position := sort.SearchInts(items, item)
+	items = append(items, 0)
+	copy(items[position+1:], items[position:])
+	items[position] = item
+	return items
+}
@@ -0,0 +1,72 @@
+package stevedore_test
+
+import (
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReleaseGraph(t *testing.T) {
+	releaseSpecification := func(name string, dependsOn ...string) stevedore.ReleaseSpecification {
+		return stevedore.ReleaseSpecification{Release: stevedore.Release{Name: name}, DependsOn: dependsOn}
+	}
+	names := func(nodes stevedore.ReleaseNodes) []string {
+		var result []string
+		for _, node := range nodes {
+			result = append(result, node.String())
+		}
+		return result
+	}
+
+	t.Run("should sort releases after the releases they depend on", func(t *testing.T) {
+		manifestFiles := stevedore.ManifestFiles{
+			{File: "app.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("app", "db", "cache"),
+				releaseSpecification("worker", "db"),
+			}}},
+			{File: "infra.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("cache"),
+				releaseSpecification("db", "external"),
+			}}},
+		}
+
+		graph, err := stevedore.NewReleaseGraph(manifestFiles)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cache (infra.yaml)", "db (infra.yaml)", "app (app.yaml)", "worker (app.yaml)"}, names(graph.Sorted()))
+	})
+
+	t.Run("should return error with file names when there is a cycle", func(t *testing.T) {
+		manifestFiles := stevedore.ManifestFiles{
+			{File: "app.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("app", "db"),
+			}}},
+			{File: "infra.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("db", "cache"),
+				releaseSpecification("cache", "app"),
+			}}},
+		}
+
+		_, err := stevedore.NewReleaseGraph(manifestFiles)
+
+		if assert.Error(t, err) {
+			assert.IsType(t, stevedore.DependencyCycleError{}, err)
+			assert.Equal(t, "cycle detected in dependsOn: app (app.yaml) -> db (infra.yaml) -> cache (infra.yaml) -> app (app.yaml)", err.Error())
+		}
+	})
+
+	t.Run("should return error when a release depends on itself", func(t *testing.T) {
+		manifestFiles := stevedore.ManifestFiles{
+			{File: "app.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+				releaseSpecification("app", "app"),
+			}}},
+		}
+
+		_, err := stevedore.NewReleaseGraph(manifestFiles)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "cycle detected in dependsOn: app (app.yaml) -> app (app.yaml)", err.Error())
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package stevedore
+
+import "fmt"
+
+// releaseScheduler keeps track of the releases in a ReleaseGraph
+// which are ready to be upstalled, in progress and completed
+type releaseScheduler struct {
+	graph              ReleaseGraph
+	honorDependencies  bool
+	pending            []int
+	failedDependencies [][]string
+	ready              []int
+	inProgress         map[string][]int
+	running            int
+}
+
+func newReleaseScheduler(graph ReleaseGraph, honorDependencies bool) *releaseScheduler {
+	scheduler := &releaseScheduler{
+		graph:              graph,
+		honorDependencies:  honorDependencies,
+		pending:            make([]int, len(graph.Nodes)),
+		failedDependencies: make([][]string, len(graph.Nodes)),
+		inProgress:         map[string][]int{},
+	}
+
+	for index := range graph.Nodes {
+		if honorDependencies {
+			scheduler.pending[index] = len(graph.dependencies[index])
+		}
+		if scheduler.pending[index] == 0 {
+			scheduler.ready = append(scheduler.ready, index)
+		}
+	}
+	return scheduler
+}
+
+// next returns the next release which is ready to be upstalled
+func (scheduler *releaseScheduler) next() (int, bool) {
+	if len(scheduler.ready) == 0 {
+		return -1, false
+	}
+	index := scheduler.ready[0]
+	scheduler.ready = scheduler.ready[1:]
+	return index, true
+}
+
+// skipped returns DependencyFailedError if any of the releases the given release depends on has failed
+func (scheduler *releaseScheduler) skipped(index int) error {
+	if failed := scheduler.failedDependencies[index]; len(failed) != 0 {
+		return DependencyFailedError{ReleaseName: scheduler.graph.Nodes[index].Release.Name, Dependencies: failed}
+	}
+	return nil
+}
+
+// start marks the release as in progress
+func (scheduler *releaseScheduler) start(index int) {
+	key := scheduler.key(scheduler.graph.Nodes[index].File, scheduler.graph.Nodes[index].Release.Name)
+	scheduler.inProgress[key] = append(scheduler.inProgress[key], index)
+	scheduler.running++
+}
+
+// isRunning returns true if any release is in progress
+func (scheduler *releaseScheduler) isRunning() bool {
+	return scheduler.running != 0
+}
+
+// finish marks the in progress release which produced the response as completed.
+// It returns error for a response of a release which is not in progress,
+// since completing any other release could unblock the dependents of a release which has not finished
+func (scheduler *releaseScheduler) finish(response Response) error {
+	key := scheduler.key(response.File, response.ReleaseName)
+	indices, ok := scheduler.inProgress[key]
+	if !ok || len(indices) == 0 {
+		return fmt.Errorf("received response for %s which is not in progress", key)
+	}
+
+	if len(indices) == 1 {
+		delete(scheduler.inProgress, key)
+	} else {
+		scheduler.inProgress[key] = indices[1:]
+	}
+	scheduler.running--
+	scheduler.complete(indices[0], response.Err)
+	return nil
+}
+
+// complete marks the release as completed and
+// makes its dependents ready once all their dependencies are completed
+func (scheduler *releaseScheduler) complete(index int, err error) {
+	if !scheduler.honorDependencies {
+		return
+	}
+
+	for _, dependent := range scheduler.graph.dependents[index] {
+		if err != nil {
+			scheduler.failedDependencies[dependent] = append(scheduler.failedDependencies[dependent], scheduler.graph.Nodes[index].Release.Name)
+		}
+		scheduler.pending[dependent]--
+		if scheduler.pending[dependent] == 0 {
+			scheduler.ready = insertSorted(scheduler.ready, dependent)
+		}
+	}
+}
+
+func (scheduler *releaseScheduler) key(file, releaseName string) string {
+	return fmt.Sprintf("%s/%s", file, releaseName)
+}
@@ -2,7 +2,6 @@ package stevedore
 
 import (
 	"github.com/gojek/stevedore/pkg/config"
-	"github.com/gojek/stevedore/pkg/merger"
 )
 
 // ReleaseSpecification represents spec to be deployed
@@ -12,6 +11,8 @@ type ReleaseSpecification struct {
 	DependsOn  []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
 	Mounts     Configs  `json:"mounts,omitempty" yaml:"mounts,omitempty"`
 	substitute Substitute
+	providers  Sources
+	sensitive  Substitute
 }
 
 // NewReleaseSpecification returns an instance of spec
@@ -20,14 +21,17 @@ func NewReleaseSpecification(release Release, configs Configs, substitute Substi
 }
 
 // EnrichWith will return enriched spec with final merged values
-func (spec ReleaseSpecification) EnrichWith(context Context, overrides Overrides) ReleaseSpecification {
+func (spec ReleaseSpecification) EnrichWith(context Context, overrides Overrides) (ReleaseSpecification, error) {
 	predicate := NewPredicate(spec, context)
 	matchedOverrides := overrides.CollateBy(predicate)
-	enrichedComponent := spec.Release.EnrichValues(matchedOverrides)
+	enrichedComponent, err := spec.Release.EnrichValues(matchedOverrides, context.ConditionWeights())
+	if err != nil {
+		return spec, err
+	}
 
 	spec.Release = enrichedComponent
 
-	return spec
+	return spec, nil
 }
 
 // Replace will return spec with substituted values
@@ -36,12 +40,17 @@ func (spec ReleaseSpecification) Replace(stevedoreContext Context, envs Substitu
 		return spec, err
 	}
 
-	appConfig, err := spec.Configs.Fetch(providers, stevedoreContext)
+	pluginConfigs, sensitive, err := spec.Configs.fetchAll(providers, stevedoreContext)
 	if err != nil {
 		return spec, err
 	}
 
-	substitutes, err := appConfig.Merge(envs)
+	appConfig, err := pluginConfigs.merge(stevedoreContext.ProviderPrecedence, stevedoreContext.StrictConfigs, providers.FirstReport)
+	if err != nil {
+		return spec, err
+	}
+
+	substitutes, err := Substitute(appConfig).Merge(envs)
 
 	if err != nil {
 		return spec, err
@@ -52,8 +61,30 @@ func (spec ReleaseSpecification) Replace(stevedoreContext Context, envs Substitu
 		return spec, err
 	}
 
+	replacedComponent.provenance = spec.Release.provenance.substitute(spec.Release.Values, replacedComponent.Values, func(placeholder placeholder) Source {
+		if value, ok := substitutes[placeholder.name]; !ok || (value == "" && placeholder.operator == defaultOperator) {
+			return Source{Type: DefaultSource, Variable: placeholder.name}
+		}
+		if _, ok := envs[placeholder.name]; ok {
+			return Source{Type: EnvSource, Variable: placeholder.name}
+		}
+		source, _ := pluginConfigs.source(stevedoreContext.ProviderPrecedence, placeholder.name)
+		return source
+	})
 	spec.Release = replacedComponent
 	spec.substitute = substitutes
+	spec.providers = Sources{}
+	for name := range replacedComponent.usedSubstitute {
+		if _, ok := envs[name]; ok {
+			continue
+		}
+		if source, ok := pluginConfigs.source(stevedoreContext.ProviderPrecedence, name); ok {
+			spec.providers[name] = source
+			if sensitive.contains(source.Provider, name) {
+				spec.sensitive = spec.sensitive.with(name, substitutes[name])
+			}
+		}
+	}
 
 	return spec, nil
 }
@@ -66,12 +97,14 @@ func (spec ReleaseSpecification) Mount(stevedoreContext Context, providers confi
 		return spec, nil
 	}
 
-	mountedConfigs, err := spec.Mounts.Fetch(providers, stevedoreContext)
-	if err != nil {
-		return spec, err
-	}
-
-	mergedConfigs, err := merger.Merge(spec.Release.Values, mountedConfigs)
+	mergedConfigs, err := spec.Mounts.mergeInto(spec.Release.Values, providers, stevedoreContext, func(provider string, before, after, config map[string]interface{}, sensitive []string) {
+		spec.Release.provenance = spec.Release.provenance.track(before, after, config, Source{Type: MountSource, Provider: provider})
+		for _, key := range sensitive {
+			for path, value := range leaves(map[string]interface{}{key: config[key]}) {
+				spec.sensitive = spec.sensitive.with(path, value)
+			}
+		}
+	})
 	if err != nil {
 		return spec, err
 	}
@@ -92,6 +125,21 @@ func (spec ReleaseSpecification) SubstitutedVariables() Substitute {
 	return spec.Release.usedSubstitute
 }
 
+// SensitiveValues returns the values fetched from the sensitive config providers, by the name of the variable
+// or the path of the mounted value
+func (spec ReleaseSpecification) SensitiveValues() Substitute {
+	return spec.sensitive
+}
+
+// VariableProviders returns the name of the config provider which supplied each of the substituted variables
+func (spec ReleaseSpecification) VariableProviders() map[string]string {
+	result := make(map[string]string, len(spec.providers))
+	for name, source := range spec.providers {
+		result[name] = source.Provider
+	}
+	return result
+}
+
 // ContainsDependency returns whether the spec contains the given chart name as dependency
 func (spec ReleaseSpecification) ContainsDependency(chartName string) (Dependencies, bool) {
 	return spec.Release.ChartSpec.Dependencies.Contains(chartName)
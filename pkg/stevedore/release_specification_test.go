@@ -64,10 +64,34 @@ func TestReleaseSpecificationEnrichWith(t *testing.T) {
 			},
 		}
 
-		actual := releaseSpecification.EnrichWith(stevedore.Context{EnvironmentType: "staging"}, overrides)
+		actual, err := releaseSpecification.EnrichWith(stevedore.Context{EnvironmentType: "staging"}, overrides)
 
+		assert.NoError(t, err)
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("should merge the overrides by the condition precedence of the context", func(t *testing.T) {
+		releaseSpecification := stevedore.ReleaseSpecification{
+			Release: stevedore.Release{Name: "x-stevedore", Namespace: "ns", Values: stevedore.Values{"region": "none"}},
+		}
+		overrides := stevedore.Overrides{
+			Spec: stevedore.OverrideSpecifications{
+				{Matches: stevedore.Conditions{"labels.region": "eu-west"}, Values: stevedore.Values{"region": "eu-west"}},
+				{Matches: stevedore.Conditions{"environmentType": "staging"}, Values: stevedore.Values{"region": "staging"}},
+			},
+		}
+		ctx := stevedore.Context{EnvironmentType: "staging", Labels: stevedore.Labels{"region": "eu-west"}}
+
+		byDefault, err := releaseSpecification.EnrichWith(ctx, overrides)
+		assert.NoError(t, err)
+
+		ctx.ConditionPrecedence = []string{"labels.region"}
+		byPrecedence, err := releaseSpecification.EnrichWith(ctx, overrides)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "staging", byDefault.Release.Values["region"])
+		assert.Equal(t, "eu-west", byPrecedence.Release.Values["region"])
+	})
 }
 
 func TestReleaseSpecificationReplace(t *testing.T) {
@@ -132,6 +156,7 @@ func TestReleaseSpecificationReplace(t *testing.T) {
 		if !cmp.Equal(expected, actual, unexported) {
 			assert.Fail(t, cmp.Diff(expected, actual, unexported), "expected to be equal")
 		}
+		assert.Equal(t, map[string]string{"ENV": "store", "OPTION2": "store"}, actual.VariableProviders())
 	})
 
 	t.Run("should not fetch variables from store if there are no variables for replacement", func(t *testing.T) {
@@ -421,3 +446,68 @@ func TestReleaseSpecificationContainsDependency(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+type sensitiveProvider struct {
+	staticProvider
+}
+
+func (sensitiveProvider) Sensitive() bool {
+	return true
+}
+
+// sensitiveKeysProvider returns the static configs, of which only the given keys are sensitive
+type sensitiveKeysProvider struct {
+	staticProvider
+	keys []string
+}
+
+func (provider sensitiveKeysProvider) FetchSensitiveKeys(map[string]string, interface{}) (map[string]interface{}, []string, error) {
+	return provider.staticProvider, provider.keys, nil
+}
+
+func TestReleaseSpecificationSensitiveValues(t *testing.T) {
+	providers := config.Providers{
+		{Name: "store", Provider: staticProvider{"NAME": "x-service"}},
+		{Name: "vault", Provider: sensitiveProvider{staticProvider{"DB_PASSWORD": "password", "db": map[string]interface{}{"username": "user"}}}},
+		{Name: "kubernetes", Provider: sensitiveKeysProvider{staticProvider{"API_KEY": "key", "LOG_LEVEL": "info", "redis": map[string]interface{}{"password": "secret"}}, []string{"API_KEY", "redis"}}},
+	}
+
+	t.Run("should return the substituted variables fetched from the sensitive providers", func(t *testing.T) {
+		releaseSpecification := stevedore.ReleaseSpecification{
+			Release: stevedore.Release{Name: "x-service", Values: stevedore.Values{"name": "${NAME}", "password": "${DB_PASSWORD}"}},
+			Configs: stevedore.Configs{"store": nil, "vault": nil},
+		}
+
+		actual, err := releaseSpecification.Replace(stevedore.Context{}, stevedore.Substitute{}, providers)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"DB_PASSWORD": "password"}, actual.SensitiveValues())
+	})
+
+	t.Run("should return the values mounted from the sensitive providers", func(t *testing.T) {
+		releaseSpecification := stevedore.ReleaseSpecification{
+			Release: stevedore.Release{Name: "x-service", Values: stevedore.Values{"name": "x-service"}},
+			Mounts:  stevedore.Configs{"store": nil, "vault": nil},
+		}
+
+		actual, err := releaseSpecification.Mount(stevedore.Context{}, providers)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"DB_PASSWORD": "password", "db.username": "user"}, actual.SensitiveValues())
+	})
+
+	t.Run("should return only the sensitive keys of the providers of which some keys are sensitive", func(t *testing.T) {
+		releaseSpecification := stevedore.ReleaseSpecification{
+			Release: stevedore.Release{Name: "x-service", Values: stevedore.Values{"apiKey": "${API_KEY}", "logLevel": "${LOG_LEVEL}"}},
+			Configs: stevedore.Configs{"kubernetes": nil},
+			Mounts:  stevedore.Configs{"kubernetes": nil},
+		}
+
+		replaced, err := releaseSpecification.Replace(stevedore.Context{}, stevedore.Substitute{}, providers)
+		assert.NoError(t, err)
+		actual, err := replaced.Mount(stevedore.Context{}, providers)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stevedore.Substitute{"API_KEY": "key", "redis.password": "secret"}, actual.SensitiveValues())
+	})
+}
@@ -9,12 +9,16 @@ import (
 type ReleaseSpecifications []ReleaseSpecification
 
 // EnrichWith will return enriched applications with final merged values
-func (specs ReleaseSpecifications) EnrichWith(context Context, overrides Overrides) ReleaseSpecifications {
+func (specs ReleaseSpecifications) EnrichWith(context Context, overrides Overrides) (ReleaseSpecifications, error) {
 	applications := ReleaseSpecifications{}
 	for _, app := range specs {
-		applications = append(applications, app.EnrichWith(context, overrides))
+		enrichedApp, err := app.EnrichWith(context, overrides)
+		if err != nil {
+			return specs, err
+		}
+		applications = append(applications, enrichedApp)
 	}
-	return applications
+	return applications, nil
 }
 
 // Replace will return applications with substituted values
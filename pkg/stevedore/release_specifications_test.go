@@ -284,8 +284,9 @@ func TestReleaseSpecificationsEnrichWith(t *testing.T) {
 			},
 		}
 
-		actual := releaseSpecifications.EnrichWith(stevedore.Context{EnvironmentType: "staging", Environment: "some-specific-staging-env"}, overrides)
+		actual, err := releaseSpecifications.EnrichWith(stevedore.Context{EnvironmentType: "staging", Environment: "some-specific-staging-env"}, overrides)
 
+		assert.NoError(t, err)
 		assert.Equal(t, expected, actual)
 	})
 }
@@ -42,7 +42,9 @@ func TestComponentEnrichValues(t *testing.T) {
 			overrides:      overrides,
 		}
 
-		actual := release.EnrichValues(overrides)
+		actual, err := release.EnrichValues(overrides, defaultConditionWeights)
+
+		assert.NoError(t, err)
 
 		equals := cmp.Equal(expected, actual, cmp.AllowUnexported(Release{}))
 		if !equals {
@@ -51,6 +53,22 @@ func TestComponentEnrichValues(t *testing.T) {
 	})
 }
 
+func TestProvenanceOverride(t *testing.T) {
+	t.Run("should return error when the overrides can not be merged", func(t *testing.T) {
+		specs := OverrideSpecifications{
+			{Values: Values{"key1": "firstOverrideForK1"}},
+			{Values: Values{"$delete": true}},
+		}
+
+		provenance, err := Provenance{}.override(Values{"key1": "baseValueForK1"}, specs, defaultConditionWeights)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "invalid merge directive at root: directives can not be used at root", err.Error())
+		}
+		assert.Nil(t, provenance)
+	})
+}
+
 func TestComponentOverrides(t *testing.T) {
 	t.Run("should return overrides used for enrich", func(t *testing.T) {
 		release := Release{
@@ -72,7 +90,9 @@ func TestComponentOverrides(t *testing.T) {
 			},
 		}
 
-		actual := release.EnrichValues(expected)
+		actual, err := release.EnrichValues(expected, defaultConditionWeights)
+
+		assert.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Overrides())
 	})
@@ -0,0 +1,62 @@
+package stevedore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gojek/stevedore/pkg/helm"
+)
+
+// CreateRollbackResponse will take the manifests and produce response by rolling back the releases based on given Opts
+func CreateRollbackResponse(ctx context.Context, client helm.Client, manifestFiles ManifestFiles, opts Opts, helmTimeout int64) (Responses, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request aborted abruptly by client")
+	default:
+		s := Stevedore{Client: client, Opts: opts}
+		return s.Rollback(ctx, manifestFiles, helmTimeout), nil
+	}
+}
+
+// Rollback rolls back the releases one after another. If CurrentReleaseVersion is present (as in an artifact),
+// it is the revision planned on top of the live one, hence a release is rolled back to the revision before it,
+// which was live when it was planned. Otherwise, it is rolled back to its previous revision
+func (s Stevedore) Rollback(ctx context.Context, manifestFiles ManifestFiles, helmTimeout int64) Responses {
+	var acc Responses
+	for _, manifestFile := range manifestFiles {
+		for _, releaseSpecification := range manifestFile.Spec {
+			release := releaseSpecification.Release
+			var revision int32
+			if release.CurrentReleaseVersion > 0 {
+				revision = release.CurrentReleaseVersion - 1
+			}
+			rollbackResponse, err := s.Client.Rollback(ctx, release.Name, release.Namespace, revision, s.DryRun, helmTimeout)
+			if err != nil {
+				acc = append(acc, Response{
+					manifestFile.File,
+					release.Name,
+					release.Chart,
+					release.ChartVersion,
+					release.CurrentReleaseVersion,
+					rollbackResponse,
+					releaseError("rolling back", release.Name, err),
+				})
+				continue
+			}
+
+			response := Response{
+				manifestFile.File,
+				release.Name,
+				release.Chart,
+				rollbackResponse.ChartVersion,
+				rollbackResponse.CurrentReleaseVersion,
+				rollbackResponse,
+				nil,
+			}
+			if !s.Filter || response.HasDiff {
+				acc = append(acc, response)
+			}
+		}
+	}
+	return acc
+}
@@ -0,0 +1,66 @@
+package stevedore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gojek/stevedore/pkg/helm"
+	mocks "github.com/gojek/stevedore/pkg/internal/mocks/helm"
+	"github.com/gojek/stevedore/pkg/stevedore"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStevedoreRollback(t *testing.T) {
+	var timeout int64 = 10
+	manifestFiles := stevedore.ManifestFiles{
+		{File: "services.yaml", Manifest: stevedore.Manifest{Spec: stevedore.ReleaseSpecifications{
+			{Release: stevedore.Release{Name: "app", Namespace: "default", Chart: "chart/app"}},
+			{Release: stevedore.Release{Name: "db", Namespace: "infra", Chart: "chart/db", CurrentReleaseVersion: 3}},
+		}}},
+	}
+
+	t.Run("should roll back to previous revision or to the revision the artifact was planned against", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		client.EXPECT().Rollback(context.TODO(), "app", "default", int32(0), false, timeout).Return(helm.UpstallResponse{HasDiff: true, ChartVersion: "1.0.0", CurrentReleaseVersion: 5}, nil)
+		client.EXPECT().Rollback(context.TODO(), "db", "infra", int32(2), false, timeout).Return(helm.UpstallResponse{}, fmt.Errorf("revision 2 of release db not found"))
+
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{}}
+		responses := s.Rollback(context.TODO(), manifestFiles, timeout)
+
+		expected := stevedore.Responses{
+			{
+				File:                  "services.yaml",
+				ReleaseName:           "app",
+				ChartName:             "chart/app",
+				ChartVersion:          "1.0.0",
+				CurrentReleaseVersion: 5,
+				UpstallResponse:       helm.UpstallResponse{HasDiff: true, ChartVersion: "1.0.0", CurrentReleaseVersion: 5},
+			},
+			{
+				File:                  "services.yaml",
+				ReleaseName:           "db",
+				ChartName:             "chart/db",
+				CurrentReleaseVersion: 3,
+				Err:                   fmt.Errorf("error when rolling back db due to revision 2 of release db not found"),
+			},
+		}
+		assert.Equal(t, expected, responses)
+	})
+
+	t.Run("should only return releases having changes on dry run with filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		client.EXPECT().Rollback(context.TODO(), "app", "default", int32(0), true, timeout).Return(helm.UpstallResponse{}, nil)
+		client.EXPECT().Rollback(context.TODO(), "db", "infra", int32(2), true, timeout).Return(helm.UpstallResponse{HasDiff: true}, nil)
+
+		s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{DryRun: true, Filter: true}}
+		responses := s.Rollback(context.TODO(), manifestFiles, timeout)
+
+		assert.Equal(t, []string{"db"}, responses.GetReleaseNames())
+	})
+}
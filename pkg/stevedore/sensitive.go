@@ -0,0 +1,266 @@
+package stevedore
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedValue replaces the sensitive values when displayed
+const RedactedValue = "<redacted>"
+
+var sensitiveNamePattern = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|CREDENTIAL|PRIVATE_KEY|API_KEY|ACCESS_KEY)`)
+
+// minInterpolatedLength is the length below which a sensitive value is redacted only as a whole,
+// since a short value is likely to occur by chance as a part of another value or text
+const minInterpolatedLength = 6
+
+// scalarLinePattern splits a line of yaml, or of its diff, into the prefix and the scalar value of a key or an item
+var scalarLinePattern = regexp.MustCompile(`^([-+ ]?\s*(?:- )?(?:[^:]*?:\s+)?)(.*?)(\s*)$`)
+
+// SensitiveVariables represents the names of variables whose values are sensitive,
+// in addition to the ones which are sensitive by naming convention
+type SensitiveVariables []string
+
+// IsSensitive returns true if the variable is listed as sensitive, or if its name
+// contains PASSWORD, PASSWD, SECRET, TOKEN, CREDENTIAL, PRIVATE_KEY, API_KEY or ACCESS_KEY (ignoring case)
+func (variables SensitiveVariables) IsSensitive(name string) bool {
+	for _, variable := range variables {
+		if variable == name {
+			return true
+		}
+	}
+	return sensitiveNamePattern.MatchString(name)
+}
+
+// Redactor replaces the values of sensitive variables with RedactedValue.
+// Values are redacted as a whole, by the name of the variable, by the path of the leaf
+// or by being equal to a sensitive value. A sensitive value which is interpolated into
+// another value or text is redacted where it occurs, unless it is shorter than minInterpolatedLength
+type Redactor struct {
+	variables    SensitiveVariables
+	values       map[string]bool
+	interpolated *strings.Replacer
+}
+
+// NewRedactor returns Redactor for the values of sensitive variables in the substitutes
+func NewRedactor(variables SensitiveVariables, substitutes ...Substitute) Redactor {
+	values := map[string]bool{}
+	for _, substitute := range substitutes {
+		for name, value := range substitute {
+			if str, ok := scalar(value); ok && str != "" && variables.IsSensitive(name) {
+				values[str] = true
+			}
+		}
+	}
+	return Redactor{variables: variables, values: values, interpolated: interpolatedReplacer(values)}
+}
+
+// interpolatedReplacer returns the replacer of the sensitive values which are not too short to be redacted
+// as a part of another value, longest first so that a value containing another one is redacted as a whole
+func interpolatedReplacer(values map[string]bool) *strings.Replacer {
+	var candidates []string
+	for value := range values {
+		if len(value) >= minInterpolatedLength {
+			candidates = append(candidates, value)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i]) != len(candidates[j]) {
+			return len(candidates[i]) > len(candidates[j])
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	oldNew := make([]string, 0, 2*len(candidates))
+	for _, candidate := range candidates {
+		oldNew = append(oldNew, candidate, RedactedValue)
+	}
+	return strings.NewReplacer(oldNew...)
+}
+
+// Redact returns the text with the scalar values of its lines redacted, which are equal to a sensitive value,
+// and with the sensitive values redacted, which are interpolated into the rest of the text.
+// The text is expected to be yaml or its diff, where a line is a value, a key with a value or an item of a list
+func (redactor Redactor) Redact(text string) string {
+	if len(redactor.values) == 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+		matches := scalarLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if redactor.isSensitiveValue(unquote(matches[2])) {
+			lines[index] = matches[1] + RedactedValue + matches[3]
+		} else if redactor.isSensitiveValue(strings.TrimSpace(line)) {
+			lines[index] = RedactedValue
+		} else {
+			lines[index] = redactor.redactInterpolated(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RedactValues returns a copy of the values with the leaves redacted, which are equal to a sensitive value,
+// and with the sensitive values redacted, which are interpolated into the other leaves
+func (redactor Redactor) RedactValues(values Values) Values {
+	if values == nil {
+		return nil
+	}
+	return Values(redactor.redact(map[string]interface{}(values), "", nil, true).(map[string]interface{}))
+}
+
+// RedactReleaseValues returns a copy of the values of the release with the leaves redacted, which use a sensitive
+// variable or which are mounted from a sensitive config provider. Unless the provenance of the release is tracked,
+// the leaves which are equal to a sensitive value are redacted instead
+func (redactor Redactor) RedactReleaseValues(spec ReleaseSpecification) Values {
+	if spec.Release.Values == nil {
+		return nil
+	}
+	if spec.Release.provenance == nil {
+		return redactor.RedactValues(spec.Release.Values)
+	}
+	paths := map[string]bool{}
+	for path := range spec.sensitive {
+		paths[path] = true
+	}
+	for path, origin := range spec.Release.provenance {
+		for _, variable := range origin.Variables {
+			if redactor.variables.IsSensitive(variable.Variable) {
+				paths[path] = true
+			}
+		}
+	}
+	return Values(redactor.redact(map[string]interface{}(spec.Release.Values), "", paths, false).(map[string]interface{}))
+}
+
+// RedactSubstitute returns a copy of the substitute with the values of sensitive variables redacted
+func (redactor Redactor) RedactSubstitute(substitute Substitute) Substitute {
+	if substitute == nil {
+		return nil
+	}
+	result := make(Substitute, len(substitute))
+	for name, value := range substitute {
+		if redactor.variables.IsSensitive(name) {
+			value = RedactedValue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// RedactResponses returns a copy of the responses with sensitive values in their diff redacted
+func (redactor Redactor) RedactResponses(responses Responses) Responses {
+	result := make(Responses, 0, len(responses))
+	for _, response := range responses {
+		response.Diff = redactor.Redact(response.Diff)
+		result = append(result, response)
+	}
+	return result
+}
+
+func (redactor Redactor) isSensitiveValue(value string) bool {
+	return value != "" && redactor.values[value]
+}
+
+func (redactor Redactor) redactInterpolated(text string) string {
+	if redactor.interpolated == nil {
+		return text
+	}
+	return redactor.interpolated.Replace(text)
+}
+
+// redact returns a copy of the value with the leaves redacted, whose path is in the given paths
+// or, when matching values, which are equal to a sensitive value. Paths are formatted as in the Provenance
+func (redactor Redactor) redact(value interface{}, path string, paths map[string]bool, matchValues bool) interface{} {
+	if path != "" && paths[path] {
+		if value == nil || isLeaf(value) {
+			return RedactedValue
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[key] = redactor.redact(item, childPath(path, key), paths, matchValues)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(value))
+		for key, item := range value {
+			result[key] = redactor.redact(item, childPath(path, fmt.Sprintf("%v", key)), paths, matchValues)
+		}
+		return result
+	case Values:
+		return Values(redactor.redact(map[string]interface{}(value), path, paths, matchValues).(map[string]interface{}))
+	case []map[string]interface{}:
+		result := make([]map[string]interface{}, 0, len(value))
+		for index, item := range value {
+			result = append(result, redactor.redact(item, fmt.Sprintf("%s[%d]", path, index), paths, matchValues).(map[string]interface{}))
+		}
+		return result
+	case []map[interface{}]interface{}:
+		result := make([]map[interface{}]interface{}, 0, len(value))
+		for index, item := range value {
+			result = append(result, redactor.redact(item, fmt.Sprintf("%s[%d]", path, index), paths, matchValues).(map[interface{}]interface{}))
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for index, item := range value {
+			result = append(result, redactor.redact(item, fmt.Sprintf("%s[%d]", path, index), paths, matchValues))
+		}
+		return result
+	default:
+		if str, ok := scalar(value); ok && matchValues && redactor.isSensitiveValue(str) {
+			return RedactedValue
+		}
+		if str, ok := value.(string); ok && matchValues {
+			return redactor.redactInterpolated(str)
+		}
+		return value
+	}
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+func isLeaf(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return false
+	default:
+		return true
+	}
+}
+
+// scalar returns the value formatted as it is displayed, if it is a string, a number or a boolean
+func scalar(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", value), true
+	default:
+		return "", false
+	}
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -19,10 +19,11 @@ import (
 
 // Opts represents options to stevedore release
 type Opts struct {
-	DryRun   bool
-	Parallel bool
-	Filter   bool
-	Timeout  int64
+	DryRun      bool
+	Parallel    bool
+	Filter      bool
+	Timeout     int64
+	IgnoreDrift bool
 }
 
 // Stevedore installs or upgrades helm releases
@@ -33,8 +34,9 @@ type Stevedore struct {
 	DependencyBuilder
 }
 
-// CreateResponse will take the manifests and helmClients and produce response based on given Opts
-func CreateResponse(ctx context.Context, manifestFiles ManifestFiles, opts Opts, helmRepoName string, helmTimeout int64, helmAtomic bool) (Responses, error) {
+// CreateResponse will take the manifests and helmClient and produce response based on given Opts.
+// When a release fails, its error is returned along with the responses received until then, which include it
+func CreateResponse(ctx context.Context, client helm.Client, manifestFiles ManifestFiles, opts Opts, helmRepoName string, helmTimeout int64, helmAtomic bool) (Responses, error) {
 	select {
 	case <-ctx.Done():
 		return nil, fmt.Errorf("request aborted abruptly by client")
@@ -43,9 +45,8 @@ func CreateResponse(ctx context.Context, manifestFiles ManifestFiles, opts Opts,
 		if err != nil {
 			return nil, err
 		}
-		s := Stevedore{Client: &helm.DefaultClient{}, Opts: opts, Upstaller: HelmUpstaller{}, DependencyBuilder: dependencyBuilder}
-		responses, _ := s.Do(ctx, manifestFiles, helmTimeout, helmAtomic)
-		return responses, nil
+		s := Stevedore{Client: client, Opts: opts, Upstaller: HelmUpstaller{}, DependencyBuilder: dependencyBuilder}
+		return s.Do(ctx, manifestFiles, helmTimeout, helmAtomic)
 	}
 }
 
@@ -80,10 +81,19 @@ func noChartRepoError(repoName string) string {
 	return buff.String()
 }
 
-// Do install or upgrade releases
+// Do install or upgrade releases honoring dependsOn between them.
+// It returns DependencyCycleError without upstalling any release if dependsOn has a cycle.
+// Unless it is a dry run or parallel, it stops at the first failed release and returns its error along with the responses.
+// It also returns error along with the responses received so far, if a response does not belong to a release in progress
 func (s Stevedore) Do(ctx context.Context, manifestFiles ManifestFiles, helmTimeout int64, helmAtomic bool) (Responses, error) {
+	graph, err := NewReleaseGraph(manifestFiles)
+	if err != nil {
+		return nil, err
+	}
+
 	responseCh := make(chan Response)
-	go s.createResponses(ctx, manifestFiles, responseCh, helmTimeout, helmAtomic)
+	errCh := make(chan error, 1)
+	go s.createResponses(ctx, graph, responseCh, errCh, helmTimeout, helmAtomic)
 
 	var acc Responses
 	for response := range responseCh {
@@ -96,14 +106,15 @@ func (s Stevedore) Do(ctx context.Context, manifestFiles ManifestFiles, helmTime
 		}
 	}
 
-	return acc, nil
+	return acc, <-errCh
 }
 
-func (s Stevedore) createResponses(ctx context.Context, manifestFiles ManifestFiles, responseCh chan<- Response, helmTimeout int64, helmAtomic bool) {
+func (s Stevedore) createResponses(ctx context.Context, graph ReleaseGraph, responseCh chan<- Response, errCh chan<- error, helmTimeout int64, helmAtomic bool) {
 	var wg sync.WaitGroup
 	proceed := make(chan bool)
-	s.response(ctx, manifestFiles, &wg, responseCh, proceed, helmTimeout, helmAtomic)
+	err := s.response(ctx, graph, &wg, responseCh, proceed, helmTimeout, helmAtomic)
 	wg.Wait()
+	errCh <- err
 	close(responseCh)
 }
 
@@ -124,28 +135,75 @@ func (s Stevedore) buildChartIfNeeded(ctx context.Context, releaseSpecification
 	return builtApplication, nil
 }
 
-func (s Stevedore) response(ctx context.Context, manifestFiles ManifestFiles, wg *sync.WaitGroup, responseCh chan<- Response, proceed chan bool, helmTimeout int64, helmAtomic bool) {
-	for _, request := range manifestFiles {
-		for _, releaseSpecification := range request.Manifest.Spec {
-			releaseSpecification, err := s.buildChartIfNeeded(ctx, releaseSpecification)
+// response upstalls the releases in the graph as soon as they are ready.
+// Unless it is a dry run, a release is ready only after all the releases it depends on
+// are completed, and it is skipped if any of them has failed.
+// It stops scheduling and returns error on receiving a response of a release which is not in progress
+func (s Stevedore) response(ctx context.Context, graph ReleaseGraph, wg *sync.WaitGroup, responseCh chan<- Response, proceed chan bool, helmTimeout int64, helmAtomic bool) error {
+	scheduler := newReleaseScheduler(graph, !s.DryRun)
+	results := make(chan Response, len(graph.Nodes))
+
+	for {
+		for index, ok := scheduler.next(); ok; index, ok = scheduler.next() {
+			node := graph.Nodes[index]
+			if err := scheduler.skipped(index); err != nil {
+				log.Error(err.Error())
+				responseCh <- failedResponse(node.File, node.ReleaseSpecification, err)
+				scheduler.complete(index, err)
+				continue
+			}
+
+			releaseSpecification, err := s.buildChartIfNeeded(ctx, node.ReleaseSpecification)
 			if err != nil {
 				log.Error(err.Error())
-				responseCh <- Response{
-					request.File,
-					releaseSpecification.Release.Name,
-					releaseSpecification.Release.Chart,
-					releaseSpecification.Release.ChartVersion,
-					releaseSpecification.Release.CurrentReleaseVersion,
-					helm.UpstallResponse{},
-					fmt.Errorf("%v", err.Error()),
-				}
+				responseCh <- failedResponse(node.File, releaseSpecification, fmt.Errorf("%v", err.Error()))
+				scheduler.complete(index, err)
 				continue
 			}
+
+			scheduler.start(index)
 			wg.Add(1)
-			go s.Upstaller.Upstall(ctx, s.Client, releaseSpecification, request.File, responseCh, proceed, wg, s.Opts, helmTimeout, helmAtomic)
+			go s.Upstaller.Upstall(ctx, s.Client, releaseSpecification, node.File, results, proceed, wg, s.Opts, helmTimeout, helmAtomic)
 			if !<-proceed {
-				return
+				for scheduler.isRunning() {
+					response := <-results
+					responseCh <- response
+					if err := scheduler.finish(response); err != nil {
+						return err
+					}
+				}
+				return nil
 			}
 		}
+
+		if !scheduler.isRunning() {
+			return nil
+		}
+		response := <-results
+		responseCh <- response
+		if err := scheduler.finish(response); err != nil {
+			return err
+		}
+	}
+}
+
+func failedResponse(file string, releaseSpecification ReleaseSpecification, err error) Response {
+	return Response{
+		file,
+		releaseSpecification.Release.Name,
+		releaseSpecification.Release.Chart,
+		releaseSpecification.Release.ChartVersion,
+		releaseSpecification.Release.CurrentReleaseVersion,
+		helm.UpstallResponse{},
+		err,
+	}
+}
+
+// releaseError returns err as it is, if it denotes the kind of failure when interacting with helm,
+// otherwise it wraps err along with the action which failed
+func releaseError(action, releaseName string, err error) error {
+	if _, ok := err.(helm.Failure); ok {
+		return err
 	}
+	return fmt.Errorf("error when %s %s due to %v", action, releaseName, err)
 }
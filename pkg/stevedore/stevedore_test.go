@@ -785,4 +785,135 @@ func TestStevedoreDo(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("when releases depend on each other", func(t *testing.T) {
+		newReleaseSpecification := func(name string, dependsOn ...string) stevedore.ReleaseSpecification {
+			releaseSpecification := stevedore.NewReleaseSpecification(
+				stevedore.NewRelease(name, "default", "chart/"+name, "", stevedore.ChartSpec{}, 0, stevedore.Values{}, stevedore.Substitute{}, stevedore.Overrides{}),
+				stevedore.Configs{}, nil)
+			releaseSpecification.DependsOn = dependsOn
+			return releaseSpecification
+		}
+		manifestFiles := func(file string, releaseSpecifications ...stevedore.ReleaseSpecification) stevedore.ManifestFiles {
+			manifest := stevedore.Manifest{
+				DeployTo: stevedore.Matchers{{stevedore.ConditionContextName: "services"}},
+				Spec:     releaseSpecifications,
+			}
+			return stevedore.ManifestFiles{{File: file, Manifest: manifest}}
+		}
+
+		t.Run("should upstall a release only after the releases it depends on", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mocks.NewMockClient(ctrl)
+			upstaller := mockUpstaller.NewMockUpstaller(ctrl)
+
+			file := "services.yaml"
+			opts := stevedore.Opts{DryRun: false, Parallel: true}
+			var upstalled []string
+			upstaller.EXPECT().Upstall(context.TODO(), client, gomock.Any(), file, gomock.Any(), gomock.Any(), gomock.Any(), opts, timeout, atomic).Do(func(_, _, releaseSpecification, _, responseCh, proceedCh, wg, _ interface{}, t int64, atomic bool) {
+				defer wg.(*sync.WaitGroup).Done()
+
+				name := releaseSpecification.(stevedore.ReleaseSpecification).Release.Name
+				upstalled = append(upstalled, name)
+				proceedCh.(chan<- bool) <- true
+				responseCh.(chan<- stevedore.Response) <- stevedore.Response{File: file, ReleaseName: name}
+			}).Times(3)
+
+			s := stevedore.Stevedore{Client: client, Opts: opts, Upstaller: upstaller}
+			files := manifestFiles(file,
+				newReleaseSpecification("x-stevedore", "z-stevedore", "y-stevedore"),
+				newReleaseSpecification("y-stevedore", "z-stevedore"),
+				newReleaseSpecification("z-stevedore"),
+			)
+			responses, err := s.Do(context.TODO(), files, timeout, atomic)
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"z-stevedore", "y-stevedore", "x-stevedore"}, upstalled)
+			assert.Len(t, responses, 3)
+		})
+
+		t.Run("should skip the dependents of a failed release", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mocks.NewMockClient(ctrl)
+			upstaller := mockUpstaller.NewMockUpstaller(ctrl)
+
+			file := "services.yaml"
+			opts := stevedore.Opts{DryRun: false, Parallel: true}
+			upstaller.EXPECT().Upstall(context.TODO(), client, gomock.Any(), file, gomock.Any(), gomock.Any(), gomock.Any(), opts, timeout, atomic).Do(func(_, _, releaseSpecification, _, responseCh, proceedCh, wg, _ interface{}, t int64, atomic bool) {
+				defer wg.(*sync.WaitGroup).Done()
+
+				name := releaseSpecification.(stevedore.ReleaseSpecification).Release.Name
+				var err error
+				if name == "y-stevedore" {
+					err = fmt.Errorf("error")
+				}
+				proceedCh.(chan<- bool) <- true
+				responseCh.(chan<- stevedore.Response) <- stevedore.Response{File: file, ReleaseName: name, Err: err}
+			}).Times(2)
+
+			s := stevedore.Stevedore{Client: client, Opts: opts, Upstaller: upstaller}
+			x := newReleaseSpecification("x-stevedore", "y-stevedore")
+			files := manifestFiles(file, x, newReleaseSpecification("y-stevedore"), newReleaseSpecification("z-stevedore"))
+			responses, err := s.Do(context.TODO(), files, timeout, atomic)
+
+			expectedResponses := stevedore.Responses{
+				{File: file, ReleaseName: "y-stevedore", Err: fmt.Errorf("error")},
+				{File: file, ReleaseName: "z-stevedore"},
+				{
+					File:        file,
+					ReleaseName: "x-stevedore",
+					ChartName:   x.Release.Chart,
+					Err:         stevedore.DependencyFailedError{ReleaseName: "x-stevedore", Dependencies: []string{"y-stevedore"}},
+				},
+			}
+
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, expectedResponses, responses)
+		})
+
+		t.Run("should return error along with the responses for a response of a release which is not in progress", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mocks.NewMockClient(ctrl)
+			upstaller := mockUpstaller.NewMockUpstaller(ctrl)
+
+			file := "services.yaml"
+			opts := stevedore.Opts{DryRun: false, Parallel: true}
+			upstaller.EXPECT().Upstall(context.TODO(), client, gomock.Any(), file, gomock.Any(), gomock.Any(), gomock.Any(), opts, timeout, atomic).Do(func(_, _, releaseSpecification, _, responseCh, proceedCh, wg, _ interface{}, t int64, atomic bool) {
+				defer wg.(*sync.WaitGroup).Done()
+
+				proceedCh.(chan<- bool) <- true
+				responseCh.(chan<- stevedore.Response) <- stevedore.Response{File: file, ReleaseName: "unknown-stevedore"}
+			}).Times(1)
+
+			s := stevedore.Stevedore{Client: client, Opts: opts, Upstaller: upstaller}
+			files := manifestFiles(file, newReleaseSpecification("x-stevedore", "y-stevedore"), newReleaseSpecification("y-stevedore"))
+			responses, err := s.Do(context.TODO(), files, timeout, atomic)
+
+			assert.Equal(t, stevedore.Responses{{File: file, ReleaseName: "unknown-stevedore"}}, responses)
+			if assert.Error(t, err) {
+				assert.Equal(t, "received response for services.yaml/unknown-stevedore which is not in progress", err.Error())
+			}
+		})
+
+		t.Run("should return error without upstalling when dependsOn has a cycle", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mocks.NewMockClient(ctrl)
+			upstaller := mockUpstaller.NewMockUpstaller(ctrl)
+
+			s := stevedore.Stevedore{Client: client, Opts: stevedore.Opts{Parallel: true}, Upstaller: upstaller}
+			files := manifestFiles("services.yaml",
+				newReleaseSpecification("x-stevedore", "y-stevedore"),
+				newReleaseSpecification("y-stevedore", "x-stevedore"),
+			)
+			responses, err := s.Do(context.TODO(), files, timeout, atomic)
+
+			assert.Nil(t, responses)
+			if assert.Error(t, err) {
+				assert.Equal(t, "cycle detected in dependsOn: x-stevedore (services.yaml) -> y-stevedore (services.yaml) -> x-stevedore (services.yaml)", err.Error())
+			}
+		})
+	})
 }
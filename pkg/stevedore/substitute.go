@@ -2,22 +2,58 @@ package stevedore
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
-	stringutils "github.com/gojek/stevedore/pkg/utils/string"
 	"github.com/imdario/mergo"
+	"gopkg.in/yaml.v2"
 )
 
 var placeholderPattern *regexp.Regexp
+var expressionPattern *regexp.Regexp
 
 func init() {
-	pattern, err := regexp.Compile(`(\${\w*})`)
+	pattern, err := regexp.Compile(`\${([^{}]*)}`)
 	if err != nil {
 		panic(fmt.Errorf("[values init] %v", err))
 	}
 	placeholderPattern = pattern
+
+	pattern, err = regexp.Compile(`^\s*(\w+)\s*(?:(:-|:\?)([^|]*))?((?:\|\s*\w+\s*)*)$`)
+	if err != nil {
+		panic(fmt.Errorf("[values init] %v", err))
+	}
+	expressionPattern = pattern
+}
+
+// operators of the placeholder
+const (
+	defaultOperator  = ":-"
+	requiredOperator = ":?"
+)
+
+// functions which can be piped in the placeholder, eg: ${NAME | upper}.
+// The result of the functions is always a string, hence quote returns the value as is
+// and only makes sure that a value like 3 or true is substituted as a string
+var functions = map[string]func(string) (string, error){
+	"upper": func(value string) (string, error) { return strings.ToUpper(value), nil },
+	"lower": func(value string) (string, error) { return strings.ToLower(value), nil },
+	"trim":  func(value string) (string, error) { return strings.TrimSpace(value), nil },
+	"quote": func(value string) (string, error) { return value, nil },
+	"b64enc": func(value string) (string, error) {
+		return base64.StdEncoding.EncodeToString([]byte(value)), nil
+	},
+	"b64dec": func(value string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("unable to decode base64: %v", err)
+		}
+		return string(decoded), nil
+	},
 }
 
 // Substitute holds key value pair for substitution
@@ -35,24 +71,177 @@ func (err SubstituteError) Error() string {
 	return strings.TrimSpace(buff.String())
 }
 
-func (sub Substitute) get(key string, isInterpolated bool) string {
-	if value, ok := sub[key]; ok {
-		if _, ok = value.(string); ok && !isInterpolated {
-			return fmt.Sprintf("'%s'", value)
+// placeholder represents the expression in ${...}, which is one of
+// ${NAME}, ${NAME:-default} or ${NAME:?error message}, optionally followed by functions, eg: ${NAME | b64enc}
+type placeholder struct {
+	expression string
+	name       string
+	operator   string
+	argument   string
+	functions  []string
+}
+
+func parsePlaceholder(expression string) (placeholder, bool) {
+	matchGroups := expressionPattern.FindStringSubmatch(expression)
+	if len(matchGroups) != 5 {
+		return placeholder{}, false
+	}
+
+	var pipeline []string
+	for _, function := range strings.Split(matchGroups[4], "|")[1:] {
+		pipeline = append(pipeline, strings.TrimSpace(function))
+	}
+	return placeholder{
+		expression: expression,
+		name:       matchGroups[1],
+		operator:   matchGroups[2],
+		argument:   strings.TrimSpace(matchGroups[3]),
+		functions:  pipeline,
+	}, true
+}
+
+func (p placeholder) String() string {
+	return fmt.Sprintf("${%s}", p.expression)
+}
+
+func (p placeholder) failure(reason string) string {
+	return fmt.Sprintf("%s: %s", p, reason)
+}
+
+// evaluate returns the value of the placeholder, or the reason for failure if it can not be evaluated
+func (sub Substitute) evaluate(p placeholder) (interface{}, string) {
+	value, ok := sub[p.name]
+	isEmpty := !ok || value == ""
+	switch {
+	case isEmpty && p.operator == defaultOperator:
+		value = defaultValue(p.argument)
+	case isEmpty && p.operator == requiredOperator && p.argument != "":
+		return nil, p.failure(p.argument)
+	case !ok || (isEmpty && p.operator == requiredOperator):
+		return nil, p.String()
+	}
+
+	if len(p.functions) == 0 {
+		return value, ""
+	}
+
+	result := fmt.Sprintf("%v", value)
+	for _, name := range p.functions {
+		function, ok := functions[name]
+		if !ok {
+			return nil, p.failure(fmt.Sprintf("unknown function %s", name))
 		}
-		return fmt.Sprintf("%v", value)
+		transformed, err := function(result)
+		if err != nil {
+			return nil, p.failure(fmt.Sprintf("%s: %v", name, err))
+		}
+		result = transformed
 	}
-	return fmt.Sprintf("${%s}", key)
+	return result, ""
+}
+
+// defaultValue returns the default of the placeholder parsed as a yaml scalar, eg: 3 or true,
+// or the default as is if it is not a scalar
+func defaultValue(argument string) interface{} {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(argument), &value); err != nil {
+		return argument
+	}
+	if _, ok := scalar(value); !ok {
+		return argument
+	}
+	return value
+}
+
+// Interpolate substitutes the placeholders within the string with the values of the variables as is, without quoting them
+func (sub Substitute) Interpolate(str string) (string, error) {
+	errors := SubstituteError{}
+	result := sub.interpolate(str, &errors)
+	if len(errors) != 0 {
+		return str, errors
+	}
+	return result, nil
+}
+
+// replace returns a copy of the value with the placeholders replaced.
+// A string which is a single placeholder is replaced by the value of the variable as is, preserving its type,
+// whereas the placeholders within a larger string are interpolated
+func (sub Substitute) replace(value interface{}, errors *SubstituteError) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return sub.replaceString(value, errors)
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Map:
+		keys := reflected.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+		})
+		result := make(map[interface{}]interface{}, len(keys))
+		for _, key := range keys {
+			replacedKey := key.Interface()
+			if str, ok := replacedKey.(string); ok {
+				replacedKey = sub.interpolate(str, errors)
+			}
+			result[replacedKey] = sub.replace(reflected.MapIndex(key).Interface(), errors)
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		result := make([]interface{}, 0, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			result = append(result, sub.replace(reflected.Index(i).Interface(), errors))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func (sub Substitute) replaceString(str string, errors *SubstituteError) interface{} {
+	location := placeholderPattern.FindStringSubmatchIndex(str)
+	if location == nil || location[0] != 0 || location[1] != len(str) {
+		return sub.interpolate(str, errors)
+	}
+
+	p, ok := parsePlaceholder(str[location[2]:location[3]])
+	if !ok {
+		return str
+	}
+	value, failure := sub.evaluate(p)
+	if failure != "" {
+		*errors = append(*errors, failure)
+		return str
+	}
+	return value
+}
+
+func (sub Substitute) interpolate(str string, errors *SubstituteError) string {
+	return placeholderPattern.ReplaceAllStringFunc(str, func(match string) string {
+		p, ok := parsePlaceholder(match[2 : len(match)-1])
+		if !ok {
+			return match
+		}
+		value, failure := sub.evaluate(p)
+		if failure != "" {
+			*errors = append(*errors, failure)
+			return match
+		}
+		return fmt.Sprintf("%v", value)
+	})
 }
 
-// Perform will validate and substitute variables
-func (sub Substitute) Perform(str string) (string, error) {
-	resultStr := stringutils.Expand(str, sub.get)
-	placeHolders := placeholderPattern.FindAllString(resultStr, -1)
-	if len(placeHolders) != 0 {
-		return str, SubstituteError(placeHolders)
+// with returns a copy of the substitute along with the variable
+func (sub Substitute) with(name string, value interface{}) Substitute {
+	result := make(Substitute, len(sub)+1)
+	for key, each := range sub {
+		result[key] = each
 	}
-	return resultStr, nil
+	result[name] = value
+	return result
 }
 
 // Merge merges the substitutes and returns the result
@@ -2,7 +2,6 @@ package stevedore
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"github.com/gojek/stevedore/pkg/helm"
@@ -35,10 +34,25 @@ func (HelmUpstaller) Upstall(ctx context.Context, client helm.Client, releaseSpe
 	chartName := releaseSpecification.Release.Chart
 	chartVersion := releaseSpecification.Release.ChartVersion
 	currentReleaseVersion := releaseSpecification.Release.CurrentReleaseVersion
+	if opts.IgnoreDrift {
+		currentReleaseVersion = 0
+	}
 	values, _ := releaseSpecification.Release.Values.ToYAML()
 	upstallResponse, err = client.Upstall(ctx, manifestName, chartName, chartVersion, currentReleaseVersion, namespace, values, opts.DryRun, helmTimeout, helmAtomic)
 	chartVersion = upstallResponse.ChartVersion
 	newCurrentReleaseVersion := upstallResponse.CurrentReleaseVersion
+	if driftErr, ok := err.(helm.ReleaseDriftError); ok {
+		responseCh <- Response{
+			file,
+			manifestName,
+			chartName,
+			releaseSpecification.Release.ChartVersion,
+			currentReleaseVersion,
+			upstallResponse,
+			driftErr,
+		}
+		return
+	}
 	if err != nil {
 		responseCh <- Response{
 			file,
@@ -47,7 +61,7 @@ func (HelmUpstaller) Upstall(ctx context.Context, client helm.Client, releaseSpe
 			chartVersion,
 			newCurrentReleaseVersion,
 			upstallResponse,
-			fmt.Errorf("error when installing %s due to %v", manifestName, err),
+			releaseError("installing", manifestName, err),
 		}
 		return
 	}
@@ -356,4 +356,92 @@ func TestUpstaller_upstall(t *testing.T) {
 			wgForAssert.Wait()
 		})
 	})
+
+	t.Run("should add drift error as is to response when release has drifted since plan", func(t *testing.T) {
+		opts := stevedore.Opts{Parallel: true}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		release := stevedore.Release{
+			Name:                  "postgres",
+			Namespace:             "default",
+			Chart:                 "stable/postgresql",
+			ChartVersion:          "1.0.0",
+			CurrentReleaseVersion: 3,
+		}
+
+		valuesYaml, _ := release.Values.ToYAML()
+		driftErr := helm.ReleaseDriftError{ReleaseName: "postgres", PlannedReleaseVersion: 3, LiveReleaseVersion: 4}
+		client.EXPECT().Upstall(context.TODO(), release.Name, release.Chart, release.ChartVersion, int32(3), release.Namespace, valuesYaml, false, timeout, atomic).Return(helm.UpstallResponse{}, driftErr)
+
+		responseCh := make(chan stevedore.Response, 1)
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		proceed := make(chan bool, 1)
+		stevedore.HelmUpstaller{}.Upstall(context.TODO(), client, stevedore.ReleaseSpecification{Release: release}, "postgres.yaml", responseCh, proceed, &wg, opts, timeout, atomic)
+
+		expectedResponse := stevedore.Response{
+			File:                  "postgres.yaml",
+			ReleaseName:           "postgres",
+			ChartName:             "stable/postgresql",
+			ChartVersion:          "1.0.0",
+			CurrentReleaseVersion: 3,
+			Err:                   driftErr,
+		}
+		assert.Equal(t, expectedResponse, <-responseCh)
+	})
+
+	t.Run("should add helm failure as is to response", func(t *testing.T) {
+		opts := stevedore.Opts{Parallel: true}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		release := stevedore.Release{
+			Name:      "postgres",
+			Namespace: "default",
+			Chart:     "stable/postgresql",
+		}
+
+		valuesYaml, _ := release.Values.ToYAML()
+		initErr := helm.InitError{ReleaseName: "postgres", Namespace: "default", Err: fmt.Errorf("cluster unreachable")}
+		client.EXPECT().Upstall(context.TODO(), release.Name, release.Chart, chartVersion, int32(0), release.Namespace, valuesYaml, false, timeout, atomic).Return(helm.UpstallResponse{}, initErr)
+
+		responseCh := make(chan stevedore.Response, 1)
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		proceed := make(chan bool, 1)
+		stevedore.HelmUpstaller{}.Upstall(context.TODO(), client, stevedore.ReleaseSpecification{Release: release}, "postgres.yaml", responseCh, proceed, &wg, opts, timeout, atomic)
+
+		response := <-responseCh
+		assert.Equal(t, initErr, response.Err)
+	})
+
+	t.Run("should not pass planned release version when drift is ignored", func(t *testing.T) {
+		opts := stevedore.Opts{Parallel: true, IgnoreDrift: true}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		client := mocks.NewMockClient(ctrl)
+		release := stevedore.Release{
+			Name:                  "postgres",
+			Namespace:             "default",
+			Chart:                 "stable/postgresql",
+			CurrentReleaseVersion: 3,
+		}
+
+		valuesYaml, _ := release.Values.ToYAML()
+		client.EXPECT().Upstall(context.TODO(), release.Name, release.Chart, chartVersion, int32(0), release.Namespace, valuesYaml, false, timeout, atomic).Return(helm.UpstallResponse{CurrentReleaseVersion: 5}, nil)
+
+		responseCh := make(chan stevedore.Response, 1)
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		proceed := make(chan bool, 1)
+		stevedore.HelmUpstaller{}.Upstall(context.TODO(), client, stevedore.ReleaseSpecification{Release: release}, "postgres.yaml", responseCh, proceed, &wg, opts, timeout, atomic)
+
+		response := <-responseCh
+		assert.NoError(t, response.Err)
+		assert.Equal(t, int32(5), response.CurrentReleaseVersion)
+	})
 }
@@ -29,6 +29,7 @@ func init() {
 	validate = validator.New()
 	_ = validate.RegisterValidation("any", validateAny)
 	_ = validate.RegisterValidation("criteria", validateCriteria)
+	_ = validate.RegisterValidation("labels", validateLabels)
 	validate.RegisterStructValidation(releaseValidation, Release{})
 }
 
@@ -53,14 +54,15 @@ func contains(matchers []string, value string) bool {
 }
 
 func validateCriteria(fl validator.FieldLevel) bool {
-	values := fl.Field().MapKeys()
+	field := fl.Field()
+	values := field.MapKeys()
 
 	if len(values) == 0 {
 		return true
 	}
 
 	for _, value := range values {
-		if !contains(knownCriteria, value.String()) {
+		if !isKnownCriteria(value.String()) || !isValidCondition(field.MapIndex(value).Interface()) {
 			return false
 		}
 	}
@@ -68,6 +70,15 @@ func validateCriteria(fl validator.FieldLevel) bool {
 	return true
 }
 
+func validateLabels(fl validator.FieldLevel) bool {
+	for _, value := range fl.Field().MapKeys() {
+		if !IsValidLabel(value.String()) {
+			return false
+		}
+	}
+	return true
+}
+
 func releaseValidation(sl validator.StructLevel) {
 	release, ok := sl.Current().Interface().(Release)
 	if !ok {
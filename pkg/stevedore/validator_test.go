@@ -85,6 +85,12 @@ func TestValidateCriteria(t *testing.T) {
 		{name: "should be valid for contextType", valid: true, conditions: stevedore.Conditions{"contextType": "staging"}},
 		{name: "should be valid for contextName", valid: true, conditions: stevedore.Conditions{"contextName": "staging"}},
 		{name: "should be valid for applicationName", valid: true, conditions: stevedore.Conditions{"applicationName": "staging"}},
+		{name: "should be valid for labels", valid: true, conditions: stevedore.Conditions{"labels.region": "eu-west"}},
+		{name: "should be invalid for labels with invalid name", valid: false, conditions: stevedore.Conditions{"labels.": "eu-west"}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
+		{name: "should be valid for set, negated, glob and regex conditions", valid: true, conditions: stevedore.Conditions{"environmentType": []interface{}{"staging", "!production"}, "contextName": "!prod-*", "environment": "/^stg-[0-9]+$/"}},
+		{name: "should be invalid for empty set", valid: false, conditions: stevedore.Conditions{"environmentType": []interface{}{}}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
+		{name: "should be invalid for invalid regex", valid: false, conditions: stevedore.Conditions{"environment": "/stg-[/"}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
+		{name: "should be invalid for map condition", valid: false, conditions: stevedore.Conditions{"environment": map[interface{}]interface{}{"a": "b"}}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
 		{name: "should be invalid for unknown", valid: false, conditions: stevedore.Conditions{"unknown": "invalid"}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
 		{name: "should be invalid even if one of the key is invalid", valid: false, conditions: stevedore.Conditions{"applicationName": "stevedore", "unknown": "invalid"}, error: "Key: 'test.Matches' Error:Field validation for 'Matches' failed on the 'criteria' tag"},
 	}
@@ -3,63 +3,54 @@ package stevedore
 import (
 	"bytes"
 	"fmt"
-	"regexp"
 
 	"github.com/imdario/mergo"
 	"gopkg.in/yaml.v2"
 )
 
-var variablePattern *regexp.Regexp
-
-func init() {
-	pattern, err := regexp.Compile(`\${(\w*)}`)
-	if err != nil {
-		panic(fmt.Errorf("[values init] %v", err))
-	}
-	variablePattern = pattern
-}
-
 // Values to wrap all helm values that are passed to deploy the release specification
 type Values map[string]interface{}
 
 // MergeWith merges values with the given overrides
-func (values Values) MergeWith(overrides Overrides) Values {
+func (values Values) MergeWith(overrides Overrides) (Values, error) {
 	return overrides.MergeValuesInto(values)
 }
 
-// Replace replace all placeholder text with given value
+// Replace replace all placeholder text with given value.
+// The value which is a single placeholder is replaced with the value of the variable preserving its type,
+// such as numbers, booleans, maps and lists
 func (values Values) Replace(substitute Substitute) (Values, Substitute, error) {
 	substitutes := Substitute{}
-	valueStr, err := values.toString()
-
-	if err != nil {
-		return values, substitutes, err
+	substituteErrors := SubstituteError{}
+	replaced := substitute.replace(values, &substituteErrors)
+	if len(substituteErrors) != 0 {
+		return values, substitutes, substituteErrors
 	}
 
-	resultStr, err := substitute.Perform(valueStr)
+	resultStr, err := yaml.Marshal(replaced)
 	if err != nil {
 		return values, substitutes, err
 	}
 
 	var result = Values{}
-	err = yaml.Unmarshal([]byte(resultStr), &result)
+	err = yaml.Unmarshal(resultStr, &result)
 	if err != nil {
 		return values, substitutes, err
 	}
 
-	variables, err := values.Variables()
+	placeholders, err := values.placeholders()
 
 	if err != nil {
 		return result, substitutes, nil
 	}
 
 	errors := SubstituteError{}
-	for _, variable := range variables {
-		if value, ok := substitute[variable]; ok {
-			if value == "" {
-				errors = append(errors, fmt.Sprintf("${%s}", variable))
+	for _, placeholder := range placeholders {
+		if value, ok := substitute[placeholder.name]; ok {
+			if value == "" && placeholder.operator == "" {
+				errors = append(errors, placeholder.String())
 			}
-			substitutes[variable] = value
+			substitutes[placeholder.name] = value
 		}
 	}
 
@@ -89,20 +80,50 @@ func (values Values) ToYAML() (string, error) {
 	return buffer.String(), nil
 }
 
-// Variables returns all placeholders
+// Variables returns the names of all the variables referred by placeholders
 func (values Values) Variables() ([]string, error) {
-	valueStr, err := values.toString()
+	placeholders, err := values.placeholders()
 
 	if err != nil {
 		return nil, err
 	}
 
-	placeHolders := placeholderPattern.FindAllString(valueStr, -1)
 	var result []string
-	for _, placeHolder := range placeHolders {
-		matchGroups := variablePattern.FindStringSubmatch(placeHolder)
-		if len(matchGroups) == 2 {
-			result = append(result, matchGroups[1])
+	for _, placeholder := range placeholders {
+		result = append(result, placeholder.name)
+	}
+	return result, nil
+}
+
+// UndefinedVariables returns the names of the variables referred by placeholders without a default,
+// which are not defined in the given substitute
+func (values Values) UndefinedVariables(substitute Substitute) ([]string, error) {
+	placeholders, err := values.placeholders()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, placeholder := range placeholders {
+		if _, ok := substitute[placeholder.name]; !ok && placeholder.operator != defaultOperator {
+			result = append(result, placeholder.name)
+		}
+	}
+	return result, nil
+}
+
+func (values Values) placeholders() ([]placeholder, error) {
+	valueStr, err := values.toString()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []placeholder
+	for _, matchGroups := range placeholderPattern.FindAllStringSubmatch(valueStr, -1) {
+		if placeholder, ok := parsePlaceholder(matchGroups[1]); ok {
+			result = append(result, placeholder)
 		}
 	}
 	return result, nil
@@ -83,8 +83,9 @@ func TestValuesMergeWith(t *testing.T) {
 			"key3": "fourthOverrideForK3",
 		}
 
-		actual := baseValues.MergeWith(overrides)
+		actual, err := baseValues.MergeWith(overrides)
 
+		assert.NoError(t, err)
 		if !reflect.DeepEqual(actual, expected) {
 			t.Errorf("Actual: %#v did not match \nExpected: %#v", actual, expected)
 		}
@@ -145,6 +146,140 @@ func TestValuesReplace(t *testing.T) {
 		assert.Empty(t, usedSubstitute)
 		assert.Equal(t, values, actual)
 	})
+
+	t.Run("should replace placeholders with defaults and functions", func(t *testing.T) {
+		values := stevedore.Values{
+			"name":     "${NAME:-x-service}",
+			"type":     "${TYPE:-worker}",
+			"password": "${PASSWORD | b64enc}",
+			"url":      "http://${HOST:-localhost}:${PORT:-8080}",
+		}
+
+		expected := stevedore.Values{
+			"name":     "x-service",
+			"type":     "server",
+			"password": "c2VjcmV0",
+			"url":      "http://localhost:8080",
+		}
+
+		actual, usedSubstitute, err := values.Replace(stevedore.Substitute{"NAME": "", "TYPE": "server", "PASSWORD": "secret"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, stevedore.Substitute{"NAME": "", "TYPE": "server", "PASSWORD": "secret"}, usedSubstitute)
+	})
+
+	t.Run("should not replace placeholders without braces", func(t *testing.T) {
+		values := stevedore.Values{"name": "${NAME}", "type": "$TYPE", "url": "http://$HOST:${PORT}"}
+
+		actual, _, err := values.Replace(stevedore.Substitute{"NAME": "x-service", "TYPE": "worker", "HOST": "localhost", "PORT": 8080})
+
+		assert.Nil(t, err)
+		assert.Equal(t, stevedore.Values{"name": "x-service", "type": "$TYPE", "url": "http://$HOST:8080"}, actual)
+	})
+
+	t.Run("should fail with the message when required variable is not present or empty", func(t *testing.T) {
+		values := stevedore.Values{
+			"name": "${NAME:?name of the service is required}",
+			"type": "${TYPE:?}",
+			"url":  "http://${URL:?}",
+		}
+
+		actual, _, err := values.Replace(stevedore.Substitute{"URL": ""})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, "Unable to replace 3 variable(s):\n\t1. ${NAME:?name of the service is required}: name of the service is required\n\t2. ${TYPE:?}\n\t3. ${URL:?}", err.Error())
+		}
+		assert.Equal(t, values, actual)
+	})
+
+	t.Run("should apply the functions in order", func(t *testing.T) {
+		values := stevedore.Values{
+			"name":    "${NAME | upper}",
+			"mixed":   "${TYPE:-Worker | lower | quote}",
+			"decoded": "${ENCODED | b64dec}",
+			"url":     "http://${HOST | trim | lower}:8080",
+		}
+
+		actual, _, err := values.Replace(stevedore.Substitute{"NAME": "x-service", "ENCODED": "eC1zZXJ2aWNl", "HOST": " LocalHost "})
+
+		assert.Nil(t, err)
+		expected := stevedore.Values{"name": "X-SERVICE", "mixed": "worker", "decoded": "x-service", "url": "http://localhost:8080"}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should fail for unknown functions", func(t *testing.T) {
+		values := stevedore.Values{"name": "${NAME | reverse}", "decoded": "${ENCODED | b64dec}"}
+
+		_, _, err := values.Replace(stevedore.Substitute{"NAME": "x-service", "ENCODED": "%%"})
+
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "${NAME | reverse}: unknown function reverse")
+			assert.Contains(t, err.Error(), "${ENCODED | b64dec}: b64dec: unable to decode base64")
+		}
+	})
+
+	t.Run("should replace whole value placeholders preserving the type", func(t *testing.T) {
+		values := stevedore.Values{
+			"replicaCount": "${REPLICAS}",
+			"enabled":      "${ENABLED}",
+			"version":      "${VERSION}",
+			"tolerations":  "${TOLERATIONS}",
+			"resources":    map[interface{}]interface{}{"limits": "${LIMITS}"},
+			"hosts":        []interface{}{"${HOST}", "api.${DOMAIN}"},
+			"url":          "http://${HOST}:${REPLICAS}",
+		}
+		substitute := stevedore.Substitute{
+			"REPLICAS":    3,
+			"ENABLED":     true,
+			"VERSION":     "1.10",
+			"TOLERATIONS": []interface{}{map[string]interface{}{"key": "dedicated", "operator": "Exists"}},
+			"LIMITS":      map[string]interface{}{"cpu": "100m", "memory": 128},
+			"HOST":        "localhost",
+			"DOMAIN":      "example.com",
+		}
+
+		expected := stevedore.Values{
+			"replicaCount": 3,
+			"enabled":      true,
+			"version":      "1.10",
+			"tolerations":  []interface{}{map[interface{}]interface{}{"key": "dedicated", "operator": "Exists"}},
+			"resources":    map[interface{}]interface{}{"limits": map[interface{}]interface{}{"cpu": "100m", "memory": 128}},
+			"hosts":        []interface{}{"localhost", "api.example.com"},
+			"url":          "http://localhost:3",
+		}
+
+		actual, _, err := values.Replace(substitute)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should replace whole value placeholders with defaults as yaml scalars and functions as string", func(t *testing.T) {
+		values := stevedore.Values{
+			"replicaCount": "${REPLICAS:-3}",
+			"enabled":      "${ENABLED:-true}",
+			"version":      "${VERSION:-'1.10'}",
+			"level":        "${LEVEL:-info}",
+			"timeout":      "${TIMEOUT:-3 | quote}",
+			"name":         "${NAME | upper}",
+			"port":         "${PORT | quote}",
+		}
+
+		actual, _, err := values.Replace(stevedore.Substitute{"NAME": "x-service", "PORT": 8080})
+
+		assert.Nil(t, err)
+		expected := stevedore.Values{
+			"replicaCount": 3,
+			"enabled":      true,
+			"version":      "1.10",
+			"level":        "info",
+			"timeout":      "3",
+			"name":         "X-SERVICE",
+			"port":         "8080",
+		}
+		assert.Equal(t, expected, actual)
+	})
 }
 
 func TestValuesToYAML(t *testing.T) {
@@ -181,4 +316,34 @@ func TestValuesVariables(t *testing.T) {
 		assert.Equal(t, []string{"HoSt", "NESTED_VALUE", "someUrl"}, vars)
 		assert.Nil(t, err)
 	})
+
+	t.Run("should get the variables referred with defaults, required markers and functions", func(t *testing.T) {
+		values := stevedore.Values{
+			"name":     "${NAME:-x-service}",
+			"password": "${PASSWORD | b64enc}",
+			"type":     "${TYPE:?type is required}",
+			"url":      "http://${HOST}:${PORT:-8080}",
+		}
+
+		vars, err := values.Variables()
+
+		assert.Equal(t, []string{"NAME", "PASSWORD", "TYPE", "HOST", "PORT"}, vars)
+		assert.Nil(t, err)
+	})
+}
+
+func TestValuesUndefinedVariables(t *testing.T) {
+	t.Run("should get the variables without a default which are not defined", func(t *testing.T) {
+		values := stevedore.Values{
+			"name":     "${NAME:-x-service}",
+			"password": "${PASSWORD | b64enc}",
+			"type":     "${TYPE:?type is required}",
+			"url":      "http://${HOST}:${PORT:-8080}",
+		}
+
+		vars, err := values.UndefinedVariables(stevedore.Substitute{"HOST": "localhost"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"PASSWORD", "TYPE"}, vars)
+	})
 }
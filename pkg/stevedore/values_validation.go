@@ -0,0 +1,68 @@
+package stevedore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gojek/stevedore/pkg/helm"
+)
+
+// ValuesError represents a problem with a key of the values of a release, along with the source which introduced the key
+type ValuesError struct {
+	Release string
+	Path    string
+	Reason  string
+	Value   interface{}
+	// Unknown is true when the chart has no schema and the key is not present in its default values
+	Unknown bool
+	Source  *Source
+}
+
+// Error returns the underlying error
+func (err ValuesError) Error() string {
+	if err.Source == nil {
+		return fmt.Sprintf("release %s: %s: %s", err.Release, err.Path, err.Reason)
+	}
+	return fmt.Sprintf("release %s: %s: %s, introduced by %s", err.Release, err.Path, err.Reason, err.Source)
+}
+
+// ValuesErrors represents collection of ValuesError
+type ValuesErrors []ValuesError
+
+// Error returns the underlying error
+func (errs ValuesErrors) Error() string {
+	msg := strings.Builder{}
+	msg.WriteString(fmt.Sprintf("found %d issue(s) in values:", len(errs)))
+	for index, err := range errs {
+		msg.WriteString(fmt.Sprintf("\n\t%d. %s", index+1, err.Error()))
+	}
+	return msg.String()
+}
+
+// ValidateValues validates the values of the release against the schema of its chart located using the locator.
+// The releases which build their chart are not validated
+func (spec ReleaseSpecification) ValidateValues(locator helm.ChartLocator) (ValuesErrors, error) {
+	if spec.HasBuildStep() {
+		return nil, nil
+	}
+
+	chart, err := locator.Locate(spec.Release.Chart, spec.Release.ChartVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := helm.ValidateValues(chart, spec.Release.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ValuesErrors
+	for _, issue := range issues {
+		valuesError := ValuesError{Release: spec.Release.Name, Path: issue.Path, Reason: issue.Message, Value: issue.Value, Unknown: issue.Unknown}
+		if source, ok := spec.Release.provenance.SourceOf(issue.Path); ok {
+			valuesError.Source = &source
+		}
+		result = append(result, valuesError)
+	}
+	return result, nil
+}